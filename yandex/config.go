@@ -21,6 +21,7 @@ import (
 	"github.com/mitchellh/go-homedir"
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	"github.com/yandex-cloud/go-sdk/iamkey"
+	"github.com/yandex-cloud/go-sdk/operation"
 	"github.com/yandex-cloud/go-sdk/pkg/requestid"
 	"github.com/yandex-cloud/go-sdk/pkg/retry"
 	"google.golang.org/grpc"
@@ -45,6 +46,8 @@ type Config struct {
 	Plaintext                      bool
 	Insecure                       bool
 	MaxRetries                     int
+	RetryBaseDelay                 time.Duration
+	OperationPollInterval          time.Duration
 	StorageEndpoint                string
 	YMQEndpoint                    string
 
@@ -65,6 +68,9 @@ type Config struct {
 	userAgent       string
 	sdk             *ycsdk.SDK
 	defaultS3Client *s3.S3
+
+	// redisCache keeps values looked up by Redis resources and data sources once per provider
+	redisCache *redisProviderCache
 }
 
 // this function return context with added client trace id
@@ -80,6 +86,7 @@ func (c *Config) ContextWithTimeout(timeout time.Duration) (context.Context, con
 // Client configures and returns a fully initialized Yandex.Cloud sdk
 func (c *Config) initAndValidate(stopContext context.Context, terraformVersion string, sweeper bool) error {
 	c.contextWithClientTraceID = requestid.ContextWithClientTraceID(stopContext, uuid.New().String())
+	c.redisCache = &redisProviderCache{}
 
 	credentials, err := c.credentials()
 	if err != nil {
@@ -108,14 +115,8 @@ func (c *Config) initAndValidate(stopContext context.Context, terraformVersion s
 
 	requestIDInterceptor := requestid.Interceptor()
 
-	retryInterceptor := retry.Interceptor(
-		retry.WithMax(c.MaxRetries),
-		retry.WithCodes(codes.Unavailable),
-		retry.WithAttemptHeader(true),
-		retry.WithBackoff(backoffExponentialWithJitter(defaultExponentialBackoffBase, defaultExponentialBackoffCap)))
-
 	var interceptors = []grpc.UnaryClientInterceptor{
-		retryInterceptor,
+		c.retryInterceptor(),
 		requestIDInterceptor,
 	}
 
@@ -192,6 +193,30 @@ func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
 	return key, nil
 }
 
+// retryInterceptor returns interceptor which retries API calls failed with transient errors,
+// using max_retries and retry_base_delay provider settings
+func (c *Config) retryInterceptor() grpc.UnaryClientInterceptor {
+	base := c.RetryBaseDelay
+	if base <= 0 {
+		base = defaultExponentialBackoffBase
+	}
+
+	return retry.Interceptor(
+		retry.WithMax(c.MaxRetries),
+		retry.WithCodes(codes.Unavailable),
+		retry.WithAttemptHeader(true),
+		retry.WithBackoff(backoffExponentialWithJitter(base, defaultExponentialBackoffCap)))
+}
+
+// operationPollInterval returns the delay between polls of a long running operation,
+// falling back to the SDK default when operation_poll_interval isn't set
+func (c *Config) operationPollInterval() time.Duration {
+	if c.OperationPollInterval <= 0 {
+		return operation.DefaultPollInterval
+	}
+	return c.OperationPollInterval
+}
+
 func backoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
 	return func(attempt int) time.Duration {
 		// First call of BackoffFunc would be with attempt arq equal 0
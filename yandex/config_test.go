@@ -4,12 +4,15 @@ import (
 	"io/ioutil"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
 )
@@ -127,6 +130,42 @@ func localListener(t *testing.T) net.Listener {
 	return l
 }
 
+func TestConfigRetryInterceptorHonorsMaxRetries(t *testing.T) {
+	for _, maxRetries := range []int{0, 1, 3} {
+		config := Config{
+			MaxRetries:     maxRetries,
+			RetryBaseDelay: time.Millisecond,
+		}
+
+		calls := 0
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls++
+			return status.Error(codes.Unavailable, "service is unavailable")
+		}
+
+		err := config.retryInterceptor()(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, maxRetries+1, calls, "unexpected number of calls with max_retries = %d", maxRetries)
+	}
+}
+
+func TestConfigRetryInterceptorDoesNotRetryPermanentErrors(t *testing.T) {
+	config := Config{
+		MaxRetries:     3,
+		RetryBaseDelay: time.Millisecond,
+	}
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.InvalidArgument, "invalid argument")
+	}
+
+	err := config.retryInterceptor()(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
 func Test_iamKeyFromJSONContent(t *testing.T) {
 	content, err := ioutil.ReadFile(fakeSAKeyFile)
 	require.NoError(t, err, "fail on file read %s", fakeSAKeyFile)
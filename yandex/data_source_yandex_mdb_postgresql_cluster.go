@@ -100,6 +100,10 @@ func dataSourceYandexMDBPostgreSQLCluster() *schema.Resource {
 								},
 							},
 						},
+						"pooling_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
 						"resources": {
 							Type:     schema.TypeList,
 							Computed: true,
@@ -191,6 +195,10 @@ func dataSourceYandexMDBPostgreSQLCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"engine": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"folder_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -404,20 +412,20 @@ func dataSourceYandexMDBPostgreSQLClusterRead(d *schema.ResourceData, meta inter
 	if err != nil {
 		return err
 	}
+	for _, u := range us {
+		u["conn_limit"] = flattenPGUserConnLimit(u["conn_limit"].(int64))
+	}
 	if err := d.Set("user", us); err != nil {
 		return err
 	}
 
-	createdAt, err := getTimestamp(cluster.CreatedAt)
-	if err != nil {
-		return err
-	}
+	createdAt := getTimestampOrEmpty(cluster.CreatedAt, "created_at")
 
 	if err := d.Set("labels", cluster.Labels); err != nil {
 		return err
 	}
 
-	if err := d.Set("security_group_ids", cluster.SecurityGroupIds); err != nil {
+	if err := d.Set("security_group_ids", flattenSecurityGroupIds(cluster.SecurityGroupIds)); err != nil {
 		return err
 	}
 
@@ -436,6 +444,7 @@ func dataSourceYandexMDBPostgreSQLClusterRead(d *schema.ResourceData, meta inter
 	d.Set("folder_id", cluster.FolderId)
 	d.Set("network_id", cluster.NetworkId)
 	d.Set("environment", cluster.GetEnvironment().String())
+	d.Set("engine", mdbPostgreSQLEngine)
 	d.Set("health", cluster.GetHealth().String())
 	d.Set("status", cluster.GetStatus().String())
 	d.Set("description", cluster.Description)
@@ -280,6 +280,7 @@ func testAccDataSourceMDBPGClusterCheck(datasourceName string, resourceName stri
 		resource.TestCheckResourceAttr(datasourceName, "folder_id", folderID),
 		resource.TestCheckResourceAttr(datasourceName, "description", desc),
 		resource.TestCheckResourceAttr(datasourceName, "environment", env),
+		resource.TestCheckResourceAttr(datasourceName, "engine", "postgresql"),
 		resource.TestCheckResourceAttr(datasourceName, "labels.test_key", "test_value"),
 		resource.TestCheckResourceAttr(datasourceName, "user.#", "1"),
 		resource.TestCheckResourceAttr(datasourceName, "database.#", "1"),
@@ -0,0 +1,81 @@
+package yandex
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/postgresql/v1"
+)
+
+func dataSourceYandexMDBPostgreSQLDatabase() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceYandexMDBPostgreSQLDatabaseRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lc_collate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lc_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"extension": {
+				Type:     schema.TypeSet,
+				Set:      pgExtensionHash,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexMDBPostgreSQLDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	ctx := config.Context()
+
+	clusterID := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	db, err := config.sdk.MDB().PostgreSQL().Database().Get(ctx, &postgresql.GetDatabaseRequest{
+		ClusterId:    clusterID,
+		DatabaseName: name,
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Database %q in PostgreSQL Cluster %q", name, clusterID))
+	}
+
+	d.Set("owner", db.Owner)
+	d.Set("lc_collate", db.LcCollate)
+	d.Set("lc_type", db.LcCtype)
+
+	if err := d.Set("extension", flattenPGExtensions(db.Extensions)); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", clusterID, name))
+	return nil
+}
@@ -0,0 +1,43 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+const mdbPGDatabaseConfig = `
+data "yandex_mdb_postgresql_database" "bar" {
+  cluster_id = "${yandex_mdb_postgresql_cluster.foo.id}"
+  name       = "testdb"
+}
+`
+
+func TestAccDataSourceMDBPostgreSQLDatabase_basic(t *testing.T) {
+	t.Parallel()
+
+	pgName := acctest.RandomWithPrefix("ds-pg-database")
+	pgDesc := "PostgreSQL Database Terraform Datasource Test"
+	datasourceName := "data.yandex_mdb_postgresql_database.bar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMDBPGClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMDBPGClusterConfigMain(pgName, pgDesc) + mdbPGDatabaseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "cluster_id",
+						"yandex_mdb_postgresql_cluster.foo", "id"),
+					resource.TestCheckResourceAttr(datasourceName, "name", "testdb"),
+					resource.TestCheckResourceAttr(datasourceName, "owner", "alice"),
+					resource.TestCheckResourceAttr(datasourceName, "lc_collate", "en_US.UTF-8"),
+					resource.TestCheckResourceAttr(datasourceName, "lc_type", "en_US.UTF-8"),
+					resource.TestCheckResourceAttr(datasourceName, "extension.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package yandex
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/postgresql/v1"
+)
+
+func dataSourceYandexMDBPostgreSQLUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceYandexMDBPostgreSQLUserRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"login": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"grants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"permission": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Set:      pgUserPermissionHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"conn_limit": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"settings": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexMDBPostgreSQLUserRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	ctx := config.Context()
+
+	clusterID := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	user, err := config.sdk.MDB().PostgreSQL().User().Get(ctx, &postgresql.GetUserRequest{
+		ClusterId: clusterID,
+		UserName:  name,
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("User %q in PostgreSQL Cluster %q", name, clusterID))
+	}
+
+	u, err := flattenPGUser(user, mdbPGUserSettingsFieldsInfo)
+	if err != nil {
+		return err
+	}
+
+	// API never returns the password, so it is always empty here
+	d.Set("password", "")
+	d.Set("login", u["login"])
+	d.Set("conn_limit", flattenPGUserConnLimit(u["conn_limit"].(int64)))
+
+	if err := d.Set("grants", u["grants"]); err != nil {
+		return err
+	}
+
+	if err := d.Set("permission", u["permission"]); err != nil {
+		return err
+	}
+
+	if err := d.Set("settings", u["settings"]); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", clusterID, name))
+	return nil
+}
@@ -0,0 +1,43 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+const mdbPGUserConfig = `
+data "yandex_mdb_postgresql_user" "bar" {
+  cluster_id = "${yandex_mdb_postgresql_cluster.foo.id}"
+  name       = "alice"
+}
+`
+
+func TestAccDataSourceMDBPostgreSQLUser_basic(t *testing.T) {
+	t.Parallel()
+
+	pgName := acctest.RandomWithPrefix("ds-pg-user")
+	pgDesc := "PostgreSQL User Terraform Datasource Test"
+	datasourceName := "data.yandex_mdb_postgresql_user.bar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMDBPGClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMDBPGClusterConfigMain(pgName, pgDesc) + mdbPGUserConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "cluster_id",
+						"yandex_mdb_postgresql_cluster.foo", "id"),
+					resource.TestCheckResourceAttr(datasourceName, "name", "alice"),
+					resource.TestCheckResourceAttr(datasourceName, "login", "true"),
+					resource.TestCheckResourceAttr(datasourceName, "password", ""),
+					resource.TestCheckResourceAttr(datasourceName, "permission.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "grants.#", "0"),
+				),
+			},
+		},
+	})
+}
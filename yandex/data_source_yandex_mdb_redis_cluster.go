@@ -1,8 +1,8 @@
 package yandex
 
 import (
-	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 
@@ -29,6 +29,11 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 				Computed: true,
 				Optional: true,
 			},
+			"allow_partial_hosts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"network_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -37,6 +42,10 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"engine": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"config": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -117,6 +126,30 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"shards": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -127,6 +160,7 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 			"labels": {
 				Type:     schema.TypeMap,
 				Computed: true,
+				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -138,6 +172,14 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"config_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tls_ca_cert": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"created_at": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -156,6 +198,22 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 				Set:      schema.HashString,
 				Computed: true,
 			},
+			"planned_operation": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"info": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"delayed_until": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"maintenance_window": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -183,15 +241,16 @@ func dataSourceYandexMDBRedisCluster() *schema.Resource {
 
 func dataSourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
-	ctx := context.Background()
+	ctx := config.Context()
 
-	err := checkOneOf(d, "cluster_id", "name")
+	err := checkOneOf(d, "cluster_id", "name", "labels")
 	if err != nil {
 		return err
 	}
 
 	clusterID := d.Get("cluster_id").(string)
 	_, clusterNameOk := d.GetOk("name")
+	_, clusterLabelsOk := d.GetOk("labels")
 
 	if clusterNameOk {
 		clusterID, err = resolveObjectID(ctx, config, d, sdkresolvers.RedisClusterResolver)
@@ -200,6 +259,24 @@ func dataSourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{
 		}
 	}
 
+	if clusterLabelsOk {
+		folderID, err := getFolderID(d, config)
+		if err != nil {
+			return fmt.Errorf("failed to resolve data source Redis Cluster by labels: %v", err)
+		}
+
+		clusters, err := listRedisClusters(ctx, config, folderID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve data source Redis Cluster by labels: %v", err)
+		}
+
+		labels := convertStringMap(d.Get("labels").(map[string]interface{}))
+		clusterID, err = resolveRedisClusterIDByLabels(clusters, labels)
+		if err != nil {
+			return fmt.Errorf("failed to resolve data source Redis Cluster by labels: %v", err)
+		}
+	}
+
 	cluster, err := config.sdk.MDB().Redis().Cluster().Get(ctx, &redis.GetClusterRequest{
 		ClusterId: clusterID,
 	})
@@ -207,22 +284,9 @@ func dataSourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{
 		return handleNotFoundError(err, d, fmt.Sprintf("Cluster %q", d.Get("name").(string)))
 	}
 
-	hosts := []*redis.Host{}
-	pageToken := ""
-	for {
-		resp, err := config.sdk.MDB().Redis().Cluster().ListHosts(ctx, &redis.ListClusterHostsRequest{
-			ClusterId: clusterID,
-			PageSize:  defaultMDBPageSize,
-			PageToken: pageToken,
-		})
-		if err != nil {
-			return fmt.Errorf("Error while getting list of hosts for '%s': %s", clusterID, err)
-		}
-		hosts = append(hosts, resp.Hosts...)
-		if resp.NextPageToken == "" {
-			break
-		}
-		pageToken = resp.NextPageToken
+	hosts, err := listRedisClusterHosts(ctx, config.sdk.MDB().Redis().Cluster(), clusterID, d.Get("allow_partial_hosts").(bool))
+	if err != nil {
+		return err
 	}
 
 	createdAt, err := getTimestamp(cluster.CreatedAt)
@@ -236,11 +300,22 @@ func dataSourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{
 	d.Set("folder_id", cluster.FolderId)
 	d.Set("network_id", cluster.NetworkId)
 	d.Set("environment", cluster.GetEnvironment().String())
+	d.Set("engine", mdbRedisEngine)
 	d.Set("health", cluster.GetHealth().String())
 	d.Set("status", cluster.GetStatus().String())
 	d.Set("description", cluster.Description)
 	d.Set("sharded", cluster.Sharded)
 	d.Set("tls_enabled", cluster.TlsEnabled)
+	d.Set("config_fingerprint", redisConfigFingerprint(cluster.Config))
+
+	caCert := ""
+	if cluster.TlsEnabled {
+		caCert, err = getRedisTLSCACert(ctx, config)
+		if err != nil {
+			return err
+		}
+	}
+	d.Set("tls_ca_cert", caCert)
 
 	conf := extractRedisConfig(cluster.Config)
 	err = d.Set("config", []map[string]interface{}{
@@ -276,11 +351,15 @@ func dataSourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	if err := d.Set("shards", flattenRedisShardHostCounts(hosts, cluster.Sharded)); err != nil {
+		return err
+	}
+
 	if err := d.Set("labels", cluster.Labels); err != nil {
 		return err
 	}
 
-	if err := d.Set("security_group_ids", cluster.SecurityGroupIds); err != nil {
+	if err := d.Set("security_group_ids", flattenSecurityGroupIds(cluster.SecurityGroupIds)); err != nil {
 		return err
 	}
 
@@ -289,7 +368,28 @@ func dataSourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	if err := d.Set("planned_operation", flattenRedisPlannedOperation(cluster.PlannedOperation)); err != nil {
+		return err
+	}
+
 	d.SetId(cluster.Id)
 
 	return nil
 }
+
+// resolveRedisClusterIDByLabels returns the ID of the only cluster that has all of the given labels.
+func resolveRedisClusterIDByLabels(clusters []*redis.Cluster, labels map[string]string) (string, error) {
+	matched := filterRedisClustersByLabels(clusters, labels)
+	switch len(matched) {
+	case 0:
+		return "", fmt.Errorf("no Redis Cluster matches labels %v", labels)
+	case 1:
+		return matched[0].Id, nil
+	default:
+		ids := make([]string, 0, len(matched))
+		for _, c := range matched {
+			ids = append(ids, c.Id)
+		}
+		return "", fmt.Errorf("multiple Redis Clusters match labels %v: %s", labels, strings.Join(ids, ", "))
+	}
+}
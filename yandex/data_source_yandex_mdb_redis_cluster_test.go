@@ -2,11 +2,15 @@ package yandex
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
 )
 
 func TestAccDataSourceMDBRedisCluster_byID(t *testing.T) {
@@ -159,8 +163,10 @@ func testAccDataSourceMDBRedisClusterCheck(datasourceName string, resourceName s
 	folderID := getExampleFolderID()
 	env := "PRESTABLE"
 	tlsEnabledStr := "false"
+	tlsCACertCheck := resource.TestCheckResourceAttr(datasourceName, "tls_ca_cert", "")
 	if tlsEnabled != nil && *tlsEnabled {
 		tlsEnabledStr = "true"
+		tlsCACertCheck = resource.TestMatchResourceAttr(datasourceName, "tls_ca_cert", regexp.MustCompile("-----BEGIN CERTIFICATE-----"))
 	}
 
 	return resource.ComposeTestCheckFunc(
@@ -170,11 +176,16 @@ func testAccDataSourceMDBRedisClusterCheck(datasourceName string, resourceName s
 		resource.TestCheckResourceAttr(datasourceName, "folder_id", folderID),
 		resource.TestCheckResourceAttr(datasourceName, "description", desc),
 		resource.TestCheckResourceAttr(datasourceName, "environment", env),
+		resource.TestCheckResourceAttr(datasourceName, "engine", "redis"),
 		resource.TestCheckResourceAttr(datasourceName, "labels.test_key", "test_value"),
 		resource.TestCheckResourceAttr(datasourceName, "sharded", "false"),
 		resource.TestCheckResourceAttr(datasourceName, "tls_enabled", tlsEnabledStr),
+		tlsCACertCheck,
+		resource.TestCheckResourceAttrPair(datasourceName, "config_fingerprint", resourceName, "config_fingerprint"),
 		resource.TestCheckResourceAttr(datasourceName, "host.#", "1"),
 		resource.TestCheckResourceAttrSet(datasourceName, "host.0.fqdn"),
+		resource.TestCheckResourceAttr(datasourceName, "host.0.role", "MASTER"),
+		resource.TestCheckResourceAttrSet(datasourceName, "host.0.health"),
 		testAccCheckCreatedAtAttr(datasourceName),
 		resource.TestCheckResourceAttr(datasourceName, "security_group_ids.#", "1"),
 		resource.TestCheckResourceAttr(datasourceName, "maintenance_window.0.type", "WEEKLY"),
@@ -205,3 +216,27 @@ func testAccDataSourceMDBRedisClusterConfig(redisName, redisDesc string, tlsEnab
 	return testAccMDBRedisClusterConfigMain(redisName, redisDesc, tlsEnabled, version, "hm1.nano", 16,
 		"") + mdbRedisClusterByNameConfig
 }
+
+func TestResolveRedisClusterIDByLabels(t *testing.T) {
+	clusters := []*redis.Cluster{
+		{Id: "cid-1", Labels: map[string]string{"app": "cache", "env": "prod"}},
+		{Id: "cid-2", Labels: map[string]string{"app": "queue", "env": "prod"}},
+		{Id: "cid-3", Labels: map[string]string{"app": "queue", "env": "test"}},
+	}
+
+	id, err := resolveRedisClusterIDByLabels(clusters, map[string]string{"app": "cache"})
+	require.NoError(t, err)
+	require.Equal(t, "cid-1", id)
+
+	id, err = resolveRedisClusterIDByLabels(clusters, map[string]string{"app": "queue", "env": "test"})
+	require.NoError(t, err)
+	require.Equal(t, "cid-3", id)
+
+	_, err = resolveRedisClusterIDByLabels(clusters, map[string]string{"app": "queue"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cid-2")
+	require.Contains(t, err.Error(), "cid-3")
+
+	_, err = resolveRedisClusterIDByLabels(clusters, map[string]string{"app": "db"})
+	require.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package yandex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+)
+
+func dataSourceYandexMDBRedisClusters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceYandexMDBRedisClustersRead,
+		Schema: map[string]*schema.Schema{
+			"folder_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexMDBRedisClustersRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	ctx := config.Context()
+
+	folderID, err := getFolderID(d, config)
+	if err != nil {
+		return fmt.Errorf("Error getting folder ID while reading Redis Clusters: %s", err)
+	}
+
+	clusters, err := listRedisClusters(ctx, config, folderID)
+	if err != nil {
+		return err
+	}
+
+	labels := convertStringMap(d.Get("labels").(map[string]interface{}))
+	clusters = filterRedisClustersByLabels(clusters, labels)
+
+	if err := d.Set("clusters", flattenRedisClusterSummaries(clusters)); err != nil {
+		return err
+	}
+	d.Set("folder_id", folderID)
+	d.SetId(redisClustersDataSourceID(folderID, labels))
+
+	return nil
+}
+
+func listRedisClusters(ctx context.Context, config *Config, folderID string) ([]*redis.Cluster, error) {
+	clusters := []*redis.Cluster{}
+	pageToken := ""
+	for {
+		resp, err := config.sdk.MDB().Redis().Cluster().List(ctx, &redis.ListClustersRequest{
+			FolderId:  folderID,
+			PageSize:  defaultMDBPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error while getting list of Redis Clusters in folder %q: %s", folderID, err)
+		}
+
+		clusters = append(clusters, resp.Clusters...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	return clusters, nil
+}
+
+// Keeps the clusters that have all of the given labels with the same values.
+func filterRedisClustersByLabels(clusters []*redis.Cluster, labels map[string]string) []*redis.Cluster {
+	res := []*redis.Cluster{}
+	for _, c := range clusters {
+		matches := true
+		for k, v := range labels {
+			if lv, ok := c.Labels[k]; !ok || lv != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func flattenRedisClusterSummaries(clusters []*redis.Cluster) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	for _, c := range clusters {
+		res = append(res, map[string]interface{}{
+			"id":          c.Id,
+			"name":        c.Name,
+			"status":      c.GetStatus().String(),
+			"environment": c.GetEnvironment().String(),
+		})
+	}
+	return res
+}
+
+func redisClustersDataSourceID(folderID string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString(fmt.Sprintf("%s=%s;", k, labels[k]))
+	}
+	return fmt.Sprintf("%s/%d", folderID, hashcode.String(buf.String()))
+}
@@ -0,0 +1,112 @@
+package yandex
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+)
+
+const mdbRedisClustersConfig = `
+data "yandex_mdb_redis_clusters" "bar" {
+  labels = {
+    test_key = "test_value"
+  }
+
+  depends_on = [yandex_mdb_redis_cluster.foo]
+}
+
+data "yandex_mdb_redis_clusters" "none" {
+  labels = {
+    test_key = "no_such_value"
+  }
+
+  depends_on = [yandex_mdb_redis_cluster.foo]
+}
+`
+
+func TestAccDataSourceMDBRedisClusters_labels(t *testing.T) {
+	t.Parallel()
+
+	redisName := acctest.RandomWithPrefix("ds-redis-clusters")
+	redisDesc := "Redis Clusters Terraform Datasource Test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMDBRedisClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMDBRedisClusterConfigMain(redisName, redisDesc, nil, "6.0", "hm1.nano", 16, "") +
+					mdbRedisClustersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceMDBRedisClustersContains("data.yandex_mdb_redis_clusters.bar",
+						"yandex_mdb_redis_cluster.foo", redisName),
+					resource.TestCheckResourceAttr("data.yandex_mdb_redis_clusters.none", "clusters.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMDBRedisClustersContains(datasourceName, resourceName, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[datasourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", datasourceName)
+		}
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		attrs := ds.Primary.Attributes
+		count, err := strconv.Atoi(attrs["clusters.#"])
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			prefix := fmt.Sprintf("clusters.%d.", i)
+			if attrs[prefix+"id"] != rs.Primary.ID {
+				continue
+			}
+			if attrs[prefix+"name"] != name {
+				return fmt.Errorf("Expected cluster name %q, got %q", name, attrs[prefix+"name"])
+			}
+			if attrs[prefix+"environment"] != "PRESTABLE" {
+				return fmt.Errorf("Expected cluster environment PRESTABLE, got %q", attrs[prefix+"environment"])
+			}
+			return nil
+		}
+		return fmt.Errorf("Cluster %s not found in %s", rs.Primary.ID, datasourceName)
+	}
+}
+
+func TestFilterRedisClustersByLabels(t *testing.T) {
+	clusters := []*redis.Cluster{
+		{Id: "cid-1", Labels: map[string]string{"env": "prod", "team": "a"}},
+		{Id: "cid-2", Labels: map[string]string{"env": "prod"}},
+		{Id: "cid-3", Labels: map[string]string{"env": "test", "team": "a"}},
+		{Id: "cid-4"},
+	}
+
+	ids := func(cs []*redis.Cluster) []string {
+		res := []string{}
+		for _, c := range cs {
+			res = append(res, c.Id)
+		}
+		return res
+	}
+
+	require.Equal(t, []string{"cid-1", "cid-2", "cid-3", "cid-4"}, ids(filterRedisClustersByLabels(clusters, nil)))
+	require.Equal(t, []string{"cid-1", "cid-2"}, ids(filterRedisClustersByLabels(clusters, map[string]string{"env": "prod"})))
+	require.Equal(t, []string{"cid-1"}, ids(filterRedisClustersByLabels(clusters, map[string]string{"env": "prod", "team": "a"})))
+	require.Empty(t, filterRedisClustersByLabels(clusters, map[string]string{"env": "stage"}))
+	require.NotNil(t, filterRedisClustersByLabels(clusters, map[string]string{"env": "stage"}))
+}
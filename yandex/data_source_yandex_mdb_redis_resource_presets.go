@@ -0,0 +1,112 @@
+package yandex
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+)
+
+func dataSourceYandexMDBRedisResourcePresets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceYandexMDBRedisResourcePresetsRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_presets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zones": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"cores": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexMDBRedisResourcePresetsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	presets, err := getRedisResourcePresets(config)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	if err := d.Set("resource_presets", flattenRedisResourcePresets(filterRedisResourcePresetsByZone(presets, zone))); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("redis-resource-presets/%s", zone))
+
+	return nil
+}
+
+// Keeps the presets available in the zone, all of them if the zone is empty.
+func filterRedisResourcePresetsByZone(presets []*redis.ResourcePreset, zone string) []*redis.ResourcePreset {
+	if zone == "" {
+		return presets
+	}
+
+	res := []*redis.ResourcePreset{}
+	for _, p := range presets {
+		for _, z := range p.ZoneIds {
+			if z == zone {
+				res = append(res, p)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// Presets are ordered by cores, memory and ID, so that the list is stable and the smallest presets come first.
+func flattenRedisResourcePresets(presets []*redis.ResourcePreset) []map[string]interface{} {
+	sorted := make([]*redis.ResourcePreset, len(presets))
+	copy(sorted, presets)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Cores != sorted[j].Cores {
+			return sorted[i].Cores < sorted[j].Cores
+		}
+		if sorted[i].Memory != sorted[j].Memory {
+			return sorted[i].Memory < sorted[j].Memory
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+
+	res := []map[string]interface{}{}
+	for _, p := range sorted {
+		zones := make([]string, len(p.ZoneIds))
+		copy(zones, p.ZoneIds)
+		sort.Strings(zones)
+
+		res = append(res, map[string]interface{}{
+			"id":     p.Id,
+			"zones":  zones,
+			"cores":  int(p.Cores),
+			"memory": toGigabytesInFloat(p.Memory),
+		})
+	}
+	return res
+}
@@ -0,0 +1,67 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+)
+
+const mdbRedisResourcePresetsConfig = `
+data "yandex_mdb_redis_resource_presets" "all" {}
+
+data "yandex_mdb_redis_resource_presets" "zone" {
+  zone = "ru-central1-a"
+}
+`
+
+func TestAccDataSourceMDBRedisResourcePresets_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: mdbRedisResourcePresetsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.yandex_mdb_redis_resource_presets.all", "resource_presets.0.id"),
+					resource.TestCheckResourceAttrSet("data.yandex_mdb_redis_resource_presets.all", "resource_presets.0.cores"),
+					resource.TestCheckResourceAttrSet("data.yandex_mdb_redis_resource_presets.zone", "resource_presets.0.memory"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenRedisResourcePresets(t *testing.T) {
+	presets := []*redis.ResourcePreset{
+		{Id: "hm1.micro", ZoneIds: []string{"ru-central1-b", "ru-central1-a"}, Cores: 2, Memory: 8 << 30},
+		{Id: "b1.nano", ZoneIds: []string{"ru-central1-a"}, Cores: 2, Memory: 2 << 30},
+		{Id: "hm1.nano", ZoneIds: []string{"ru-central1-c"}, Cores: 2, Memory: 4 << 30},
+		{Id: "hm1.medium", ZoneIds: []string{"ru-central1-a", "ru-central1-c"}, Cores: 4, Memory: 16 << 30},
+	}
+
+	ids := func(ps []map[string]interface{}) []string {
+		res := []string{}
+		for _, p := range ps {
+			res = append(res, p["id"].(string))
+		}
+		return res
+	}
+
+	flat := flattenRedisResourcePresets(presets)
+	require.Equal(t, []string{"b1.nano", "hm1.nano", "hm1.micro", "hm1.medium"}, ids(flat))
+	require.Equal(t, []string{"ru-central1-a", "ru-central1-b"}, flat[2]["zones"])
+	require.Equal(t, 8.0, flat[2]["memory"])
+	require.Equal(t, 2, flat[2]["cores"])
+
+	// the input order doesn't matter
+	require.Equal(t, flat, flattenRedisResourcePresets([]*redis.ResourcePreset{presets[3], presets[2], presets[1], presets[0]}))
+
+	require.Equal(t, []string{"b1.nano", "hm1.micro", "hm1.medium"}, ids(flattenRedisResourcePresets(filterRedisResourcePresetsByZone(presets, "ru-central1-a"))))
+	require.Len(t, filterRedisResourcePresetsByZone(presets, ""), 4)
+	require.Empty(t, filterRedisResourcePresetsByZone(presets, "ru-central1-d"))
+}
@@ -110,10 +110,10 @@ func (fieldsInfo *objectFieldsInfo) intCheckSetValue(field string, v *int) error
 
 	if fieldInfo, ok := fieldsInfo.fieldsManual[field]; ok {
 		if fieldInfo.minIntVal != nil && *fieldInfo.minIntVal > *v {
-			return fmt.Errorf("intCheckSetValue: min value for %s is %v value is %v", field, fieldInfo.minIntVal, v)
+			return fmt.Errorf("intCheckSetValue: min value for %s is %v value is %v", field, *fieldInfo.minIntVal, *v)
 		}
 		if fieldInfo.maxMaxVal != nil && *fieldInfo.maxMaxVal < *v {
-			return fmt.Errorf("intCheckSetValue: max value for %s is %v value is %v", field, fieldInfo.maxMaxVal, v)
+			return fmt.Errorf("intCheckSetValue: max value for %s is %v value is %v", field, *fieldInfo.maxMaxVal, *v)
 		}
 	}
 
@@ -220,6 +220,15 @@ func defaultStringToFloat(v string) (*float64, error) {
 		return nil, nil
 	}
 
+	// integers are parsed as such first, so that the ones float64 can't hold exactly are not rounded silently
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+		f := float64(i)
+		if int64(f) != i {
+			return nil, &typeMismatchError{text: fmt.Sprintf("value %s can't be represented as float without loss of precision", v)}
+		}
+		return &f, nil
+	}
+
 	f, err := strconv.ParseFloat(v, 64)
 	if err == nil {
 		return &f, nil
@@ -386,6 +395,17 @@ func (fieldsInfo *objectFieldsInfo) addIDefault(field string, def int) *objectFi
 	return fieldsInfo
 }
 
+// addIntRange keeps other manual info of the field, so it can be combined with addIDefault
+func (fieldsInfo *objectFieldsInfo) addIntRange(field string, min int, max int) *objectFieldsInfo {
+
+	fieldInfo := fieldsInfo.fieldsManual[field]
+	fieldInfo.minIntVal = &min
+	fieldInfo.maxMaxVal = &max
+	fieldsInfo.fieldsManual[field] = fieldInfo
+
+	return fieldsInfo
+}
+
 // default value is 0
 func (fieldsInfo *objectFieldsInfo) addEnumGeneratedNames(field string, values map[int32]string) *objectFieldsInfo {
 
@@ -2,6 +2,7 @@ package yandex
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -335,6 +336,47 @@ func flattenResourceGenerateMapS(v interface{}, includeNil bool,
 	return nil, nil
 }
 
+// normalizeMapSNumbers formats numeric values of map of strings canonically by field types,
+// so that "100.0" of int field becomes "100" and "0.50" of float field becomes "0.5"
+func normalizeMapSNumbers(fieldsInfo *objectFieldsInfo, m map[string]string) map[string]string {
+	if fieldsInfo == nil || fieldsInfo.nameFieldsType == nil {
+		return m
+	}
+
+	for k, v := range m {
+		if v == "" || fieldsInfo.isStringable(k) {
+			continue
+		}
+
+		for _, t := range fieldsInfo.nameFieldsType[k] {
+			fi := fieldsInfo.getType(t, k)
+			if fi.valueType != schema.TypeInt && fi.valueType != schema.TypeFloat {
+				continue
+			}
+
+			// integers are parsed as such first, float64 can't hold integers above 2^53 exactly
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				m[k] = strconv.FormatInt(i, 10)
+				break
+			}
+
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				break
+			}
+			if fi.valueType == schema.TypeInt && f == math.Trunc(f) {
+				m[k] = strconv.FormatInt(int64(f), 10)
+			}
+			if fi.valueType == schema.TypeFloat {
+				m[k] = strconv.FormatFloat(f, 'f', -1, 64)
+			}
+			break
+		}
+	}
+
+	return m
+}
+
 // expandResourceGenerate fill v from resource data
 // v must be ptr
 func expandResourceGenerateNonSkippedFields(fieldsInfo *objectFieldsInfo, d *schema.ResourceData, v interface{}, path string, skipNil bool) ([]string, error) {
@@ -405,6 +447,40 @@ func expandResourceGenerate(fieldsInfo *objectFieldsInfo, d *schema.ResourceData
 
 	return nil
 }
+
+// expandResourceGenerateMap fill v from map of settings the same way as expandResourceGenerate does from resource data
+// v must be ptr
+func expandResourceGenerateMap(fieldsInfo *objectFieldsInfo, m map[string]interface{}, v interface{}, path string, skipNil bool) error {
+
+	if v == nil {
+		return nil
+	}
+
+	t, err := getStructType(v)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return nil
+	}
+
+	fields := fieldsInfo.getFields(t)
+
+	for field, fieldInfo := range fields {
+		if !fieldsInfo.skip(field) {
+			value, ok := m[field]
+			if ok {
+				err = expandResourceGenerateOneField(fieldsInfo, fieldInfo, field, v, value, skipNil, path+field)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func expandResourceGenerateOneField(fieldsInfo *objectFieldsInfo, fieldInfo fieldReflectInfo, field string, obj interface{}, value interface{}, skipNil bool, path string) error {
 	if value == nil && skipNil {
 		return nil
@@ -289,6 +289,19 @@ func TestExpandResourceGenerateOneFieldFloat(t *testing.T) {
 		t.Errorf("Expand fail: expand into field G (float64) should return nil error when pass string value convertable to int (\"7.6\"), but error: %v", err)
 	}
 
+	err = expandResourceGenerateOneField(fieldsInfo, fiG, "g_name", testStruct, "9007199254740992", true, "some.path.for.error")
+	if err != nil {
+		t.Errorf("Expand fail: expand into field G (float64) should return nil error when pass integer string value exactly representable as float (\"9007199254740992\"), but error: %v", err)
+	}
+	if testStruct.G != 9007199254740992 {
+		t.Errorf("Expand fail: expand into field G (float64) should change value to 9007199254740992, not to %v", testStruct.G)
+	}
+
+	err = expandResourceGenerateOneField(fieldsInfo, fiG, "g_name", testStruct, "9007199254740993", true, "some.path.for.error")
+	if !isTypeMismatchError(err) {
+		t.Errorf("Expand fail: expand into field G (float64) should return an error when pass integer string value not representable as float (\"9007199254740993\")")
+	}
+
 }
 
 func TestExpandResourceGenerateOneFieldWrappersFloat(t *testing.T) {
@@ -457,6 +470,66 @@ func TestFieldsDynamicGenerateMapSchemaValidateFuncFail(t *testing.T) {
 
 }
 
+func TestFieldsDynamicGenerateMapSchemaValidateFuncIntRange(t *testing.T) {
+	t.Parallel()
+
+	validateFunc := generateMapSchemaValidateFunc(mdbPGSettingsFieldsInfo)
+
+	value := map[string]interface{}{
+		"statement_timeout":                   "0",
+		"idle_in_transaction_session_timeout": "2147483647",
+		"log_min_duration_statement":          "-1",
+	}
+
+	_, errors := validateFunc(value, "")
+
+	if len(errors) > 0 {
+		for _, err := range errors {
+			t.Errorf("generateMapSchemaValidateFunc: Error on correct example: %v", err)
+		}
+	}
+
+	value = map[string]interface{}{
+		// less than min value 0
+		"statement_timeout": "-1",
+		// greater than max value 2147483647
+		"idle_in_transaction_session_timeout": "2147483648",
+		// less than min value -1
+		"log_min_duration_statement": "-2",
+	}
+
+	_, errors = validateFunc(value, "")
+
+	if len(errors) != 3 {
+		t.Errorf("generateMapSchemaValidateFunc: Error on out of range example should be 3 errors, but out only: %v", len(errors))
+		for _, err := range errors {
+			t.Errorf("generateMapSchemaValidateFunc: Error on out of range example was: %v", err)
+		}
+	}
+}
+
+func TestFieldsDynamicGenerateMapSchemaDiffSuppressFuncIntRange(t *testing.T) {
+	t.Parallel()
+
+	suppressFunc := generateMapSchemaDiffSuppressFunc(mdbPGSettingsFieldsInfo)
+
+	if suppressFunc("ttt.statement_timeout", "30000", "60000", nil) {
+		t.Errorf("generateMapSchemaDiffSuppressFunc: int values should be not equal (30000 != 60000)")
+	}
+
+	if !suppressFunc("ttt.statement_timeout", "30000", "30000", nil) {
+		t.Errorf("generateMapSchemaDiffSuppressFunc: int values should be equal (30000 == 30000)")
+	}
+
+	if !suppressFunc("ttt.statement_timeout", "0", "", nil) {
+		t.Errorf("generateMapSchemaDiffSuppressFunc: int values should be equal when new value is empty")
+	}
+
+	if !suppressFunc("ttt.log_min_duration_statement", "-1", "", nil) {
+		t.Errorf("generateMapSchemaDiffSuppressFunc: int values should be equal when new value is empty")
+	}
+}
+
 func TestFieldsDynamicGenerateMapSchemaDiffSuppressFuncFloat(t *testing.T) {
 	t.Parallel()
 
@@ -2,9 +2,12 @@ package yandex
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	wrappers "github.com/golang/protobuf/ptypes/wrappers"
@@ -57,6 +60,7 @@ func flattenPGClusterConfig(c *postgresql.ClusterConfig, d *schema.ResourceData)
 	out["autofailover"] = c.GetAutofailover().GetValue()
 	out["version"] = c.Version
 	out["pooler_config"] = poolerConf
+	out["pooling_enabled"] = isPGPoolingEnabled(c.PoolerConfig)
 	out["resources"] = resources
 	out["backup_window_start"] = backupWindowStart
 	out["performance_diagnostics"] = performanceDiagnostics
@@ -79,11 +83,16 @@ func flattenPGPoolerConfig(c *postgresql.ConnectionPoolerConfig) ([]interface{},
 	return []interface{}{out}, nil
 }
 
+// Connection pooling is active when pooler is configured with any mode other than unspecified
+func isPGPoolingEnabled(c *postgresql.ConnectionPoolerConfig) bool {
+	return c.GetPoolingMode() != postgresql.ConnectionPoolerConfig_POOLING_MODE_UNSPECIFIED
+}
+
 func flattenPGResources(r *postgresql.Resources) ([]interface{}, error) {
 	out := map[string]interface{}{}
-	out["resource_preset_id"] = r.ResourcePresetId
-	out["disk_size"] = toGigabytes(r.DiskSize)
-	out["disk_type_id"] = r.DiskTypeId
+	out["resource_preset_id"] = r.GetResourcePresetId()
+	out["disk_size"] = toGigabytes(r.GetDiskSize())
+	out["disk_type_id"] = r.GetDiskTypeId()
 
 	return []interface{}{out}, nil
 }
@@ -102,8 +111,9 @@ func flattenPGBackupWindowStart(t *timeofday.TimeOfDay) ([]interface{}, error) {
 }
 
 func flattenPGPerformanceDiagnostics(p *postgresql.PerformanceDiagnostics) ([]interface{}, error) {
+	// clusters that never enabled performance diagnostics don't have it at all
 	if p == nil {
-		return nil, nil
+		p = &postgresql.PerformanceDiagnostics{}
 	}
 
 	out := map[string]interface{}{}
@@ -130,6 +140,15 @@ func flattenPGSettingsSPL(settings map[string]string, d *schema.ResourceData) ma
 }
 
 func flattenPGSettings(c *postgresql.ClusterConfig, d *schema.ResourceData) (map[string]string, error) {
+	settings, err := flattenPGUserSettings(c, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeMapSNumbers(mdbPGSettingsFieldsInfo, settings), nil
+}
+
+func flattenPGUserSettings(c *postgresql.ClusterConfig, d *schema.ResourceData) (map[string]string, error) {
 
 	if cf, ok := c.PostgresqlConfig.(*postgresql.ClusterConfig_PostgresqlConfig_13); ok {
 		settings, err := flattenResourceGenerateMapS(cf.PostgresqlConfig_13.UserConfig, false, mdbPGSettingsFieldsInfo, false, true)
@@ -201,8 +220,10 @@ func flattenPGSettings(c *postgresql.ClusterConfig, d *schema.ResourceData) (map
 }
 
 func flattenPGAccess(a *postgresql.Access) ([]interface{}, error) {
+	// API omits access of clusters that have it all disabled, report it explicitly
+	// so that a configured access block with disabled values doesn't show up as a diff
 	if a == nil {
-		return nil, nil
+		a = &postgresql.Access{}
 	}
 
 	out := map[string]interface{}{}
@@ -255,11 +276,26 @@ func flattenPGUser(u *postgresql.User,
 	if err != nil {
 		return nil, err
 	}
+	if settings == nil {
+		settings = map[string]string{}
+	}
 	m["settings"] = settings
 
 	return m, nil
 }
 
+// conn_limit = -1 means no limit
+const pgUserConnLimitUnlimited = -1
+
+// API doesn't return a limit when there is no explicit one, data sources report such users with conn_limit = -1.
+// The resource keeps the value as is, so that an explicit conn_limit = 0 has no diff.
+func flattenPGUserConnLimit(connLimit int64) int64 {
+	if connLimit == 0 {
+		return pgUserConnLimitUnlimited
+	}
+	return connLimit
+}
+
 func pgUsersPasswords(users []*postgresql.UserSpec) map[string]string {
 	out := map[string]string{}
 	for _, u := range users {
@@ -748,7 +784,7 @@ func flattenPGHostsFromHostInfo(hostsInfo map[string]*pgHostInfo, isDataSource b
 		m["subnet_id"] = hostInfo.subnetID
 		m["assign_public_ip"] = hostInfo.assignPublicIP
 		m["fqdn"] = hostInfo.fqdn
-		m["role"] = hostInfo.role.String()
+		m["role"] = flattenPGHostRole(hostInfo.role)
 
 		if !isDataSource {
 			m["name"] = hostInfo.name
@@ -769,6 +805,14 @@ func flattenPGHostsFromHostInfo(hostsInfo map[string]*pgHostInfo, isDataSource b
 	return hosts, hostMasterName
 }
 
+// Hosts which are still initializing may report unknown role, every host except the master is a replica.
+func flattenPGHostRole(role postgresql.Host_Role) string {
+	if role == postgresql.Host_MASTER {
+		return role.String()
+	}
+	return postgresql.Host_REPLICA.String()
+}
+
 func flattenPGDatabases(dbs []*postgresql.Database) []map[string]interface{} {
 	out := make([]map[string]interface{}, 0)
 
@@ -1256,10 +1300,18 @@ func expandPGConfigSpecSettings(d *schema.ResourceData, configSpec *postgresql.C
 
 	path := "config.0.postgresql_config"
 
-	if _, ok := d.GetOkExists(path); ok {
+	jsonSettings, err := expandPGSettingsJSON(d)
+	if err != nil {
+		return updateFieldConfigName, err
+	}
+
+	if _, ok := d.GetOkExists(path); ok || jsonSettings != nil {
 
 		var sharedPreloadLibraries []int32
 		sharedPreloadLibValue, ok := d.GetOkExists(path + ".shared_preload_libraries")
+		if jsonSettings != nil {
+			sharedPreloadLibValue, ok = jsonSettings["shared_preload_libraries"]
+		}
 		if ok {
 			splValue := sharedPreloadLibValue.(string)
 
@@ -1364,7 +1416,11 @@ func expandPGConfigSpecSettings(d *schema.ResourceData, configSpec *postgresql.C
 			return updateFieldConfigName, nil
 		}
 
-		err := expandResourceGenerate(mdbPGSettingsFieldsInfo, d, pgConfig, path+".", true)
+		if jsonSettings != nil {
+			err = expandResourceGenerateMap(mdbPGSettingsFieldsInfo, jsonSettings, pgConfig, path+"_json.", true)
+		} else {
+			err = expandResourceGenerate(mdbPGSettingsFieldsInfo, d, pgConfig, path+".", true)
+		}
 
 		if err != nil {
 			return updateFieldConfigName, err
@@ -1375,6 +1431,67 @@ func expandPGConfigSpecSettings(d *schema.ResourceData, configSpec *postgresql.C
 	return updateFieldConfigName, nil
 }
 
+// expandPGSettingsJSON returns PostgreSQL settings from config.0.postgresql_config_json,
+// or nil when settings are specified with config.0.postgresql_config map
+func expandPGSettingsJSON(d *schema.ResourceData) (map[string]interface{}, error) {
+	s := d.Get("config.0.postgresql_config_json").(string)
+	if s == "" {
+		return nil, nil
+	}
+
+	settings, err := parsePGSettingsJSON(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, errs := generateMapSchemaValidateFunc(mdbPGSettingsFieldsInfo)(settings, "config.0.postgresql_config_json"); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return settings, nil
+}
+
+// parsePGSettingsJSON parses JSON object with PostgreSQL settings into map of strings,
+// the same as the one used for config.0.postgresql_config
+func parsePGSettingsJSON(s string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("Error while parsing PostgreSQL settings JSON: %s", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("Error while parsing PostgreSQL settings JSON: expected JSON object")
+	}
+
+	settings := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		switch value := v.(type) {
+		case string:
+			settings[k] = value
+		case json.Number:
+			settings[k] = value.String()
+		case bool:
+			settings[k] = strconv.FormatBool(value)
+		default:
+			return nil, fmt.Errorf("Error while parsing PostgreSQL settings JSON: unsupported value %v of setting %q", v, k)
+		}
+	}
+
+	return settings, nil
+}
+
+func validatePGSettingsJSON(v interface{}, k string) ([]string, []error) {
+	settings, err := parsePGSettingsJSON(v.(string))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	_, errs := generateMapSchemaValidateFunc(mdbPGSettingsFieldsInfo)(settings, k)
+	return nil, errs
+}
+
 func pgDatabasesDiff(currDBs []*postgresql.Database, targetDBs []*postgresql.DatabaseSpec) ([]string, []*postgresql.DatabaseSpec) {
 	m := map[string]bool{}
 	toAdd := []*postgresql.DatabaseSpec{}
@@ -1531,4 +1648,9 @@ var mdbPGSettingsFieldsInfo = newObjectFieldsInfo().
 	addEnumGeneratedNames("plan_cache_mode", config.PostgresqlConfig13_PlanCacheMode_name).
 	addSkipEnumGeneratedNames("shared_preload_libraries", config.PostgresqlConfig13_SharedPreloadLibraries_name, mdbPGSharedPreloadLibrariesCheck, mdbPGSharedPreloadLibrariesCompare).
 	addEnumGeneratedNames("pg_hint_plan_debug_print", config.PostgresqlConfig13_PgHintPlanDebugPrint_name).
-	addEnumGeneratedNames("pg_hint_plan_message_level", config.PostgresqlConfig13_LogLevel_name)
+	addEnumGeneratedNames("pg_hint_plan_message_level", config.PostgresqlConfig13_LogLevel_name).
+	// timeouts are in milliseconds, 0 disables the timeout
+	addIntRange("statement_timeout", 0, math.MaxInt32).
+	addIntRange("idle_in_transaction_session_timeout", 0, math.MaxInt32).
+	// -1 disables logging of statements duration
+	addIntRange("log_min_duration_statement", -1, math.MaxInt32)
@@ -0,0 +1,553 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/postgresql/v1"
+	config "github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/postgresql/v1/config"
+)
+
+func TestFlattenPGClusterConfig_NoPoolerConfig(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{})
+
+	c := &postgresql.ClusterConfig{
+		Version: "13",
+		Resources: &postgresql.Resources{
+			ResourcePresetId: "s2.micro",
+			DiskSize:         10 * (1 << 30),
+			DiskTypeId:       "network-ssd",
+		},
+	}
+
+	res, err := flattenPGClusterConfig(c, d)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	conf := res[0].(map[string]interface{})
+	require.Nil(t, conf["pooler_config"])
+	require.Equal(t, false, conf["pooling_enabled"])
+
+	require.NoError(t, d.Set("config", res))
+	require.Equal(t, false, d.Get("config.0.pooling_enabled"))
+	require.Equal(t, 0, d.Get("config.0.pooler_config.#"))
+}
+
+func TestFlattenPGClusterConfig_PoolerConfig(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{})
+
+	c := &postgresql.ClusterConfig{
+		Version: "13",
+		PoolerConfig: &postgresql.ConnectionPoolerConfig{
+			PoolingMode: postgresql.ConnectionPoolerConfig_TRANSACTION,
+		},
+	}
+
+	res, err := flattenPGClusterConfig(c, d)
+	require.NoError(t, err)
+
+	require.NoError(t, d.Set("config", res))
+	require.Equal(t, true, d.Get("config.0.pooling_enabled"))
+	require.Equal(t, "TRANSACTION", d.Get("config.0.pooler_config.0.pooling_mode"))
+}
+
+func TestFlattenPGHosts_DataSourceRoles(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{})
+
+	hosts := []*postgresql.Host{
+		{
+			Name:     "master.mdb.yandexcloud.net",
+			ZoneId:   "ru-central1-a",
+			SubnetId: "subnet-a",
+			Role:     postgresql.Host_MASTER,
+		},
+		{
+			Name:     "replica.mdb.yandexcloud.net",
+			ZoneId:   "ru-central1-b",
+			SubnetId: "subnet-b",
+			Role:     postgresql.Host_ROLE_UNKNOWN,
+		},
+		{
+			Name:              "cascade.mdb.yandexcloud.net",
+			ZoneId:            "ru-central1-c",
+			SubnetId:          "subnet-c",
+			Role:              postgresql.Host_REPLICA,
+			ReplicationSource: "replica.mdb.yandexcloud.net",
+		},
+	}
+
+	hs, _, err := flattenPGHosts(d, hosts, true)
+	require.NoError(t, err)
+	require.Len(t, hs, 3)
+
+	byFqdn := map[string]map[string]interface{}{}
+	for _, h := range hs {
+		byFqdn[h["fqdn"].(string)] = h
+	}
+
+	require.Equal(t, "MASTER", byFqdn["master.mdb.yandexcloud.net"]["role"])
+	require.Equal(t, "REPLICA", byFqdn["replica.mdb.yandexcloud.net"]["role"])
+	require.Equal(t, "REPLICA", byFqdn["cascade.mdb.yandexcloud.net"]["role"])
+
+	require.Equal(t, "", byFqdn["master.mdb.yandexcloud.net"]["replication_source"])
+	require.Equal(t, "replica.mdb.yandexcloud.net", byFqdn["cascade.mdb.yandexcloud.net"]["replication_source"])
+
+	require.NoError(t, d.Set("host", hs))
+}
+
+func TestFlattenPGHosts_Priority(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{})
+
+	hosts := []*postgresql.Host{
+		{
+			Name:     "prio.mdb.yandexcloud.net",
+			ZoneId:   "ru-central1-a",
+			SubnetId: "subnet-a",
+			Role:     postgresql.Host_MASTER,
+			Priority: &wrappers.Int64Value{Value: 5},
+		},
+		{
+			Name:     "noprio.mdb.yandexcloud.net",
+			ZoneId:   "ru-central1-b",
+			SubnetId: "subnet-b",
+			Role:     postgresql.Host_REPLICA,
+		},
+	}
+
+	hs, _, err := flattenPGHosts(d, hosts, true)
+	require.NoError(t, err)
+	require.Len(t, hs, 2)
+
+	byFqdn := map[string]map[string]interface{}{}
+	for _, h := range hs {
+		byFqdn[h["fqdn"].(string)] = h
+	}
+
+	require.Equal(t, 5, byFqdn["prio.mdb.yandexcloud.net"]["priority"])
+	require.Equal(t, 0, byFqdn["noprio.mdb.yandexcloud.net"]["priority"])
+
+	require.NoError(t, d.Set("host", hs))
+}
+
+func testPGClusterRawWithSettingsJSON(settingsJSON string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "pg-tf-name",
+		"network_id":  "net-777",
+		"environment": "PRESTABLE",
+		"config": []interface{}{
+			map[string]interface{}{
+				"version": "13",
+				"resources": []interface{}{
+					map[string]interface{}{
+						"resource_preset_id": "s2.micro",
+						"disk_size":          10,
+						"disk_type_id":       "network-ssd",
+					},
+				},
+				"postgresql_config_json": settingsJSON,
+			},
+		},
+	}
+}
+
+func TestPGAutofailoverToggleOff(t *testing.T) {
+	t.Parallel()
+
+	res := resourceYandexMDBPostgreSQLCluster()
+
+	// not set on create: the API default is used
+	d := schema.TestResourceDataRaw(t, res.Schema, testPGClusterRawWithSettingsJSON(""))
+	cs, _, err := expandPGConfigSpec(d)
+	require.NoError(t, err)
+	require.Nil(t, cs.Autofailover)
+
+	withAutofailover := func(v bool) map[string]interface{} {
+		raw := testPGClusterRawWithSettingsJSON("")
+		raw["config"].([]interface{})[0].(map[string]interface{})["autofailover"] = v
+		return raw
+	}
+
+	state := schema.TestResourceDataRaw(t, res.Schema, withAutofailover(true))
+	state.SetId("cid-777")
+	diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(withAutofailover(false)), nil)
+	require.NoError(t, err)
+	d, err = schema.InternalMap(res.Schema).Data(state.State(), diff)
+	require.NoError(t, err)
+
+	require.True(t, d.HasChange("config.0.autofailover"))
+	cs, _, err = expandPGConfigSpec(d)
+	require.NoError(t, err)
+	require.NotNil(t, cs.Autofailover)
+	require.False(t, cs.Autofailover.GetValue())
+
+	// autofailover not reported by the API is read as the API default rather than kept from the state
+	conf, err := flattenPGClusterConfig(&postgresql.ClusterConfig{Version: "13"}, state)
+	require.NoError(t, err)
+	require.Equal(t, false, conf[0].(map[string]interface{})["autofailover"])
+
+	// the data source reports a plain bool
+	ds := schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{})
+	conf, err = flattenPGClusterConfig(&postgresql.ClusterConfig{Version: "13", Autofailover: &wrappers.BoolValue{Value: true}}, ds)
+	require.NoError(t, err)
+	require.Equal(t, true, conf[0].(map[string]interface{})["autofailover"])
+}
+
+func TestParsePGSettingsJSON(t *testing.T) {
+	t.Parallel()
+
+	settings, err := parsePGSettingsJSON(`{
+		"max_connections": 395,
+		"enable_parallel_hash": true,
+		"vacuum_cleanup_index_scale_factor": 0.2,
+		"default_transaction_isolation": "TRANSACTION_ISOLATION_READ_COMMITTED",
+		"shared_preload_libraries": "SHARED_PRELOAD_LIBRARIES_AUTO_EXPLAIN,SHARED_PRELOAD_LIBRARIES_PG_HINT_PLAN"
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"max_connections":                   "395",
+		"enable_parallel_hash":              "true",
+		"vacuum_cleanup_index_scale_factor": "0.2",
+		"default_transaction_isolation":     "TRANSACTION_ISOLATION_READ_COMMITTED",
+		"shared_preload_libraries":          "SHARED_PRELOAD_LIBRARIES_AUTO_EXPLAIN,SHARED_PRELOAD_LIBRARIES_PG_HINT_PLAN",
+	}, settings)
+
+	_, errs := validatePGSettingsJSON(`{"max_connections": 395, "enable_parallel_hash": true}`, "config.0.postgresql_config_json")
+	require.Empty(t, errs)
+}
+
+func TestParsePGSettingsJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		settings string
+		errorMsg string
+	}{
+		"malformed json":   {`{"max_connections": `, "Error while parsing PostgreSQL settings JSON"},
+		"not an object":    {`[1, 2, 3]`, "Error while parsing PostgreSQL settings JSON"},
+		"null":             {`null`, "expected JSON object"},
+		"nested object":    {`{"max_connections": {"value": 395}}`, `unsupported value map[value:395] of setting "max_connections"`},
+		"unknown setting":  {`{"max_connection": 395}`, "Unsupported key config.0.postgresql_config_json.max_connection"},
+		"wrong value type": {`{"max_connections": "many"}`, "Check Fail key config.0.postgresql_config_json.max_connections"},
+		"unknown enum":     {`{"default_transaction_isolation": "SERIALIZABLE"}`, "Check Fail key config.0.postgresql_config_json.default_transaction_isolation"},
+	} {
+		_, errs := validatePGSettingsJSON(tc.settings, "config.0.postgresql_config_json")
+		require.NotEmpty(t, errs, name)
+		require.Contains(t, errs[0].Error(), tc.errorMsg, name)
+	}
+}
+
+func TestExpandPGConfigSpecSettings_JSON(t *testing.T) {
+	t.Parallel()
+
+	raw := testPGClusterRawWithSettingsJSON(`{"max_connections": 395, "enable_parallel_hash": true, "shared_preload_libraries": "SHARED_PRELOAD_LIBRARIES_AUTO_EXPLAIN"}`)
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBPostgreSQLCluster().Schema, raw)
+
+	configSpec := &postgresql.ConfigSpec{Version: "13"}
+	updateFieldConfigName, err := expandPGConfigSpecSettings(d, configSpec)
+	require.NoError(t, err)
+	require.Equal(t, "postgresql_config_13", updateFieldConfigName)
+
+	cfg := configSpec.GetPostgresqlConfig_13()
+	require.NotNil(t, cfg)
+	require.Equal(t, int64(395), cfg.GetMaxConnections().GetValue())
+	require.Equal(t, true, cfg.GetEnableParallelHash().GetValue())
+	require.Len(t, cfg.SharedPreloadLibraries, 1)
+	require.Equal(t, "SHARED_PRELOAD_LIBRARIES_AUTO_EXPLAIN", cfg.SharedPreloadLibraries[0].String())
+}
+
+func TestExpandPGConfigSpecSettings_InvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	raw := testPGClusterRawWithSettingsJSON(`{"max_connections": "many"}`)
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBPostgreSQLCluster().Schema, raw)
+
+	_, err := expandPGConfigSpecSettings(d, &postgresql.ConfigSpec{Version: "13"})
+	require.Error(t, err)
+}
+
+func TestPGSettingsJSONConflictsWithMap(t *testing.T) {
+	t.Parallel()
+
+	raw := testPGClusterRawWithSettingsJSON(`{"max_connections": 395}`)
+	raw["config"].([]interface{})[0].(map[string]interface{})["postgresql_config"] = map[string]interface{}{
+		"max_connections": "395",
+	}
+
+	res := resourceYandexMDBPostgreSQLCluster()
+	_, errs := res.Validate(terraform.NewResourceConfigRaw(raw))
+	require.NotEmpty(t, errs)
+}
+
+func TestPGSettingsStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	raw := testPGClusterRawWithSettingsJSON("")
+	pgConfig := raw["config"].([]interface{})[0].(map[string]interface{})
+	delete(pgConfig, "postgresql_config_json")
+	pgConfig["postgresql_config"] = map[string]interface{}{
+		"statement_timeout":                   "30000",
+		"idle_in_transaction_session_timeout": "60000",
+		"log_min_duration_statement":          "-1",
+	}
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBPostgreSQLCluster().Schema, raw)
+
+	configSpec := &postgresql.ConfigSpec{Version: "13"}
+	_, err := expandPGConfigSpecSettings(d, configSpec)
+	require.NoError(t, err)
+
+	cfg := configSpec.GetPostgresqlConfig_13()
+	require.NotNil(t, cfg)
+	require.Equal(t, int64(30000), cfg.GetStatementTimeout().GetValue())
+	require.Equal(t, int64(60000), cfg.GetIdleInTransactionSessionTimeout().GetValue())
+	require.Equal(t, int64(-1), cfg.GetLogMinDurationStatement().GetValue())
+
+	settings, err := flattenPGSettings(&postgresql.ClusterConfig{
+		PostgresqlConfig: &postgresql.ClusterConfig_PostgresqlConfig_13{
+			PostgresqlConfig_13: &config.PostgresqlConfigSet13{UserConfig: cfg},
+		},
+	}, d)
+	require.NoError(t, err)
+	require.Equal(t, "30000", settings["statement_timeout"])
+	require.Equal(t, "60000", settings["idle_in_transaction_session_timeout"])
+	require.Equal(t, "-1", settings["log_min_duration_statement"])
+}
+
+func TestFlattenPGUsers_NoConnLimit(t *testing.T) {
+	t.Parallel()
+
+	users := []*postgresql.User{
+		{
+			Name:      "alice",
+			ClusterId: "cid-777",
+		},
+		{
+			Name:      "bob",
+			ClusterId: "cid-777",
+			ConnLimit: 20,
+		},
+	}
+
+	us, err := flattenPGUsers(users, nil, mdbPGUserSettingsFieldsInfo)
+	require.NoError(t, err)
+	require.Len(t, us, 2)
+
+	// the resource keeps the value as is
+	require.Equal(t, int64(0), us[0]["conn_limit"])
+	require.NotNil(t, us[0]["settings"])
+	require.Empty(t, us[0]["settings"])
+	require.Equal(t, int64(20), us[1]["conn_limit"])
+
+	// data sources report no explicit limit as -1
+	require.Equal(t, int64(-1), flattenPGUserConnLimit(0))
+	require.Equal(t, int64(-1), flattenPGUserConnLimit(-1))
+	require.Equal(t, int64(20), flattenPGUserConnLimit(20))
+}
+
+func TestPGUserConnLimitValidation(t *testing.T) {
+	t.Parallel()
+
+	connLimit := resourceYandexMDBPostgreSQLCluster().Schema["user"].Elem.(*schema.Resource).Schema["conn_limit"]
+
+	for _, v := range []int{-1, 10, 50} {
+		_, errs := connLimit.ValidateFunc(v, "user.0.conn_limit")
+		require.Empty(t, errs, "conn_limit = %d should be valid", v)
+	}
+
+	_, errs := connLimit.ValidateFunc(-2, "user.0.conn_limit")
+	require.NotEmpty(t, errs)
+}
+
+func TestPGUserConnLimitUpdate(t *testing.T) {
+	t.Parallel()
+
+	res := resourceYandexMDBPostgreSQLCluster()
+	withConnLimit := func(connLimit int) map[string]interface{} {
+		raw := testPGClusterRawWithSettingsJSON("")
+		raw["user"] = []interface{}{
+			map[string]interface{}{"name": "alice", "password": "passw0rd", "conn_limit": connLimit},
+		}
+		return raw
+	}
+	testUserData := func(oldConnLimit, newConnLimit int) *schema.ResourceData {
+		state := schema.TestResourceDataRaw(t, res.Schema, withConnLimit(oldConnLimit))
+		state.SetId("cid-777")
+		diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(withConnLimit(newConnLimit)), nil)
+		require.NoError(t, err)
+		d, err := schema.InternalMap(res.Schema).Data(state.State(), diff)
+		require.NoError(t, err)
+		return d
+	}
+
+	// removing the limit is sent to the API
+	d := testUserData(50, -1)
+	require.True(t, d.HasChange("user.0.conn_limit"))
+	us, err := expandPGUser(d, &postgresql.UserSpec{ConnLimit: &wrappers.Int64Value{Value: 50}}, "user.0.")
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), us.ConnLimit.GetValue())
+
+	// explicit 0 read back from the API has no diff
+	u, err := flattenPGUser(&postgresql.User{Name: "alice", ConnLimit: 0}, mdbPGUserSettingsFieldsInfo)
+	require.NoError(t, err)
+	d = testUserData(int(u["conn_limit"].(int64)), 0)
+	require.False(t, d.HasChange("user.0.conn_limit"))
+}
+
+func TestPGMaintenanceWindowHour(t *testing.T) {
+	t.Parallel()
+
+	hour := resourceYandexMDBPostgreSQLCluster().Schema["maintenance_window"].Elem.(*schema.Resource).Schema["hour"]
+
+	for _, v := range []int{0, 12, 23} {
+		_, errs := hour.ValidateFunc(v, "maintenance_window.0.hour")
+		require.Empty(t, errs, "hour = %d should be valid", v)
+	}
+	for _, v := range []int{-1, 24} {
+		_, errs := hour.ValidateFunc(v, "maintenance_window.0.hour")
+		require.NotEmpty(t, errs, "hour = %d should be invalid", v)
+	}
+
+	mw, err := flattenPGMaintenanceWindow(&postgresql.MaintenanceWindow{
+		Policy: &postgresql.MaintenanceWindow_WeeklyMaintenanceWindow{
+			WeeklyMaintenanceWindow: &postgresql.WeeklyMaintenanceWindow{
+				Day:  postgresql.WeeklyMaintenanceWindow_MON,
+				Hour: 0,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{map[string]interface{}{"type": "WEEKLY", "day": "MON", "hour": int64(0)}}, mw)
+}
+
+func TestFlattenPGClusterConfig_NoPerformanceDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	for name, d := range map[string]*schema.ResourceData{
+		"data source": schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{}),
+		"resource":    schema.TestResourceDataRaw(t, resourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{}),
+	} {
+		c := &postgresql.ClusterConfig{
+			Version: "13",
+		}
+
+		res, err := flattenPGClusterConfig(c, d)
+		require.NoError(t, err, name)
+
+		require.NoError(t, d.Set("config", res), name)
+		require.Equal(t, 1, d.Get("config.0.performance_diagnostics.#"), name)
+		require.Equal(t, false, d.Get("config.0.performance_diagnostics.0.enabled"), name)
+		require.Equal(t, 0, d.Get("config.0.performance_diagnostics.0.sessions_sampling_interval"), name)
+		require.Equal(t, 0, d.Get("config.0.performance_diagnostics.0.statements_sampling_interval"), name)
+	}
+}
+
+func TestPGAccessRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	raw := testPGClusterRawWithSettingsJSON("")
+	raw["config"].([]interface{})[0].(map[string]interface{})["access"] = []interface{}{
+		map[string]interface{}{
+			"web_sql": true,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBPostgreSQLCluster().Schema, raw)
+
+	c := &postgresql.ClusterConfig{
+		Version: "13",
+		Access:  expandPGAccess(d),
+	}
+	require.True(t, c.Access.WebSql)
+	require.False(t, c.Access.DataLens)
+
+	res, err := flattenPGClusterConfig(c, d)
+	require.NoError(t, err)
+	require.NoError(t, d.Set("config", res))
+	require.Equal(t, true, d.Get("config.0.access.0.web_sql"))
+	require.Equal(t, false, d.Get("config.0.access.0.data_lens"))
+
+	// access with everything disabled is not returned by API
+	res, err = flattenPGClusterConfig(&postgresql.ClusterConfig{Version: "13"}, d)
+	require.NoError(t, err)
+	require.NoError(t, d.Set("config", res))
+	require.Equal(t, 1, d.Get("config.0.access.#"))
+	require.Equal(t, false, d.Get("config.0.access.0.web_sql"))
+	require.Equal(t, false, d.Get("config.0.access.0.data_lens"))
+}
+
+func TestPGPerformanceDiagnosticsValidation(t *testing.T) {
+	t.Parallel()
+
+	pd := resourceYandexMDBPostgreSQLCluster().Schema["config"].Elem.(*schema.Resource).
+		Schema["performance_diagnostics"].Elem.(*schema.Resource).Schema
+
+	cases := []struct {
+		key   string
+		value int
+		valid bool
+	}{
+		{"sessions_sampling_interval", 1, true},
+		{"sessions_sampling_interval", 86400, true},
+		{"sessions_sampling_interval", 0, false},
+		{"sessions_sampling_interval", 86401, false},
+		{"statements_sampling_interval", 1, true},
+		{"statements_sampling_interval", 86400, true},
+		{"statements_sampling_interval", 0, false},
+		{"statements_sampling_interval", 86401, false},
+	}
+	for _, c := range cases {
+		_, errs := pd[c.key].ValidateFunc(c.value, "config.0.performance_diagnostics.0."+c.key)
+		require.Equal(t, c.valid, len(errs) == 0, "%s = %d", c.key, c.value)
+	}
+}
+
+func TestFlattenPGSettings_NormalizesNumbers(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexMDBPostgreSQLCluster().Schema, map[string]interface{}{})
+
+	c := &postgresql.ClusterConfig{
+		Version: "13",
+		PostgresqlConfig: &postgresql.ClusterConfig_PostgresqlConfig_13{
+			PostgresqlConfig_13: &config.PostgresqlConfigSet13{
+				UserConfig: &config.PostgresqlConfig13{
+					MaxConnections:             &wrappers.Int64Value{Value: 100},
+					CheckpointCompletionTarget: &wrappers.DoubleValue{Value: 0.5},
+					RandomPageCost:             &wrappers.DoubleValue{Value: 4},
+				},
+			},
+		},
+	}
+
+	settings, err := flattenPGSettings(c, d)
+	require.NoError(t, err)
+	require.Equal(t, "100", settings["max_connections"])
+	require.Equal(t, "0.5", settings["checkpoint_completion_target"])
+	require.Equal(t, "4", settings["random_page_cost"])
+
+	normalized := normalizeMapSNumbers(mdbPGSettingsFieldsInfo, map[string]string{
+		"max_connections":              "100.0",
+		"checkpoint_completion_target": "0.50",
+		"random_page_cost":             "4.0",
+		"statement_timeout":            "",
+		"enable_parallel_hash":         "true",
+		"temp_file_limit":              "9007199254740993",
+	})
+	require.Equal(t, map[string]string{
+		"max_connections":              "100",
+		"checkpoint_completion_target": "0.5",
+		"random_page_cost":             "4",
+		"statement_timeout":            "",
+		"enable_parallel_hash":         "true",
+		"temp_file_limit":              "9007199254740993",
+	}, normalized)
+}
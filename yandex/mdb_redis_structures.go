@@ -1,13 +1,50 @@
 package yandex
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/golang/protobuf/ptypes"
 	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
 	config "github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1/config"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// MDB API doesn't return the CA certificate of TLS enabled clusters, it is published at the well-known location.
+var redisTLSCACertURL = "https://storage.yandexcloud.net/cloud-certs/CA.pem"
+
+const redisTLSCACertFetchTimeout = 1 * time.Minute
+
+// Values looked up once per provider, see Config.redisCache.
+type redisProviderCache struct {
+	sync.Mutex
+	tlsCACert *string
+	// nil until the presets are listed, i.e. at most once per terraform run
+	resourcePresets []*redis.ResourcePreset
+}
+
+// Config is built by the provider, the fallback serves configs built elsewhere, e.g. in tests.
+func redisProviderCacheOf(config *Config) *redisProviderCache {
+	if config.redisCache == nil {
+		return &redisProviderCache{}
+	}
+	return config.redisCache
+}
+
 type redisConfig struct {
 	timeout              int64
 	maxmemoryPolicy      string
@@ -31,6 +68,18 @@ func sortRedisHosts(hosts []*redis.Host, specs []*redis.HostSpec) {
 	}
 }
 
+func sortRedisHostsByShardAndZone(hosts []*redis.Host) {
+	sort.SliceStable(hosts, func(i, j int) bool {
+		if hosts[i].ShardName != hosts[j].ShardName {
+			return hosts[i].ShardName < hosts[j].ShardName
+		}
+		if hosts[i].ZoneId != hosts[j].ZoneId {
+			return hosts[i].ZoneId < hosts[j].ZoneId
+		}
+		return hosts[i].Name < hosts[j].Name
+	})
+}
+
 // Takes the current list of hosts and the desirable list of hosts.
 // Returns the map of hostnames to delete grouped by shard,
 // and the map of hosts to add grouped by shard as well.
@@ -42,18 +91,32 @@ func redisHostsDiff(currHosts []*redis.Host, targetHosts []*redis.HostSpec) (map
 		m[key] = append(m[key], h)
 	}
 
+	// Redis API can't move a host to another subnet, so a host only matches a desirable host
+	// in the same zone and shard with the same subnet or without explicit one, and is recreated otherwise.
+	// Hosts with the same subnet are matched first, so that hosts without explicit subnet don't take their place.
+	matched := make([]bool, len(currHosts))
+	for _, sameSubnetOnly := range []bool{true, false} {
+		for i, h := range currHosts {
+			if matched[i] {
+				continue
+			}
+			key := h.ZoneId + h.ShardName
+			hs := m[key]
+			for j, spec := range hs {
+				if spec.SubnetId == h.SubnetId || !sameSubnetOnly && spec.SubnetId == "" {
+					m[key] = append(hs[:j:j], hs[j+1:]...)
+					matched[i] = true
+					break
+				}
+			}
+		}
+	}
+
 	toDelete := map[string][]string{}
-	for _, h := range currHosts {
-		key := h.ZoneId + h.ShardName
-		hs, ok := m[key]
-		if !ok {
+	for i, h := range currHosts {
+		if !matched[i] {
 			toDelete[h.ShardName] = append(toDelete[h.ShardName], h.Name)
 		}
-		if len(hs) > 1 {
-			m[key] = hs[1:]
-		} else {
-			delete(m, key)
-		}
 	}
 
 	toAdd := map[string][]*redis.HostSpec{}
@@ -63,9 +126,128 @@ func redisHostsDiff(currHosts []*redis.Host, targetHosts []*redis.HostSpec) (map
 		}
 	}
 
+	// m is a map, sort hosts so that shards are created the same way every time
+	for _, hs := range toAdd {
+		sort.SliceStable(hs, func(i, j int) bool {
+			if hs[i].ZoneId != hs[j].ZoneId {
+				return hs[i].ZoneId < hs[j].ZoneId
+			}
+			return hs[i].SubnetId < hs[j].SubnetId
+		})
+	}
+	for _, fqdns := range toDelete {
+		sort.Strings(fqdns)
+	}
+
 	return toDelete, toAdd
 }
 
+type redisHostsUpdateAction int
+
+const (
+	redisAddShard redisHostsUpdateAction = iota
+	redisAddHosts
+	redisDeleteShard
+	redisDeleteHosts
+	redisRebalance
+)
+
+type redisHostsUpdateStep struct {
+	action    redisHostsUpdateAction
+	shardName string
+	specs     []*redis.HostSpec
+	fqdns     []string
+}
+
+// Orders the changes of hosts so that the cluster keeps its availability: all hosts and shards are added
+// before any of them are deleted, e.g. a host moved to another zone is created before the old one is deleted.
+// If shards are added without rebalancing, the cluster is rebalanced once at the end, when all hosts are in place.
+func planRedisHostsUpdate(currHosts []*redis.Host, currShards []*redis.Shard, targetHosts []*redis.HostSpec, sharded bool, autoRebalance bool) []redisHostsUpdateStep {
+	toDelete, toAdd := redisHostsDiff(currHosts, targetHosts)
+
+	existingShards := map[string]bool{}
+	for _, s := range currShards {
+		existingShards[s.Name] = true
+	}
+	targetShards := map[string]bool{}
+	for _, h := range targetHosts {
+		targetShards[h.ShardName] = true
+	}
+
+	steps := []redisHostsUpdateStep{}
+	needRebalance := false
+
+	for _, shardName := range sortedRedisShardNames(toAdd) {
+		if sharded && !existingShards[shardName] {
+			steps = append(steps, redisHostsUpdateStep{action: redisAddShard, shardName: shardName, specs: toAdd[shardName]})
+			needRebalance = needRebalance || !autoRebalance
+		} else {
+			steps = append(steps, redisHostsUpdateStep{action: redisAddHosts, shardName: shardName, specs: toAdd[shardName]})
+		}
+	}
+
+	deleteShardNames := make([]string, 0, len(toDelete))
+	for shardName := range toDelete {
+		deleteShardNames = append(deleteShardNames, shardName)
+	}
+	sort.Strings(deleteShardNames)
+
+	for _, shardName := range deleteShardNames {
+		if sharded && !targetShards[shardName] {
+			steps = append(steps, redisHostsUpdateStep{action: redisDeleteShard, shardName: shardName, fqdns: toDelete[shardName]})
+		} else {
+			steps = append(steps, redisHostsUpdateStep{action: redisDeleteHosts, shardName: shardName, fqdns: toDelete[shardName]})
+		}
+	}
+
+	if needRebalance {
+		steps = append(steps, redisHostsUpdateStep{action: redisRebalance})
+	}
+	return steps
+}
+
+// Rough duration of a single host or shard operation, used to warn about update timeouts
+// that are unlikely to be enough for the planned changes of hosts.
+const redisHostOperationEstimate = 10 * time.Minute
+
+// Counts the operations the planned steps take: hosts are added and deleted one by one,
+// while a whole shard is added or deleted, or the cluster is rebalanced, by a single operation.
+func countRedisHostOperations(steps []redisHostsUpdateStep) int {
+	count := 0
+	for _, step := range steps {
+		switch step.action {
+		case redisAddHosts:
+			count += len(step.specs)
+		case redisDeleteHosts:
+			count += len(step.fqdns)
+		default:
+			count++
+		}
+	}
+	return count
+}
+
+// Returns a warning if the update timeout is likely too short for the planned steps, an empty string otherwise.
+// It is advisory only: operations are often faster than the estimate. It is logged at apply, ResourceDiff has no timeouts.
+func redisUpdateTimeoutWarning(steps []redisHostsUpdateStep, timeout time.Duration) string {
+	count := countRedisHostOperations(steps)
+	estimate := time.Duration(count) * redisHostOperationEstimate
+	if estimate <= timeout {
+		return ""
+	}
+	return fmt.Sprintf("update timeout %s is likely too short for %d host and shard operations, which may take about %s; "+
+		"consider increasing it with the \"timeouts\" block", timeout, count, estimate)
+}
+
+func sortedRedisShardNames(hosts map[string][]*redis.HostSpec) []string {
+	names := make([]string, 0, len(hosts))
+	for name := range hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func extractRedisConfig(cc *redis.ClusterConfig) redisConfig {
 	res := redisConfig{
 		version: cc.Version,
@@ -92,6 +274,111 @@ func extractRedisConfig(cc *redis.ClusterConfig) redisConfig {
 	return res
 }
 
+// redisConfigFingerprint returns a hash of the effective settings, resources and version of the cluster,
+// so it changes whenever any of them changes. Password is not returned by API and is not taken into account.
+func redisConfigFingerprint(cc *redis.ClusterConfig) string {
+	conf := extractRedisConfig(cc)
+	r := cc.GetResources()
+
+	normalized := fmt.Sprintf("version=%q;maxmemory_policy=%q;timeout=%d;notify_keyspace_events=%q;"+
+		"slowlog_log_slower_than=%d;slowlog_max_len=%d;databases=%d;"+
+		"resource_preset_id=%q;disk_size=%d;disk_type_id=%q",
+		conf.version, conf.maxmemoryPolicy, conf.timeout, conf.notifyKeyspaceEvents,
+		conf.slowlogLogSlowerThan, conf.slowlogMaxLen, conf.databases,
+		r.GetResourcePresetId(), r.GetDiskSize(), r.GetDiskTypeId())
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(normalized)))
+}
+
+// redisEffectiveConfigJSON returns effective settings and resources of the cluster as JSON.
+// Password is never included.
+func redisEffectiveConfigJSON(cc *redis.ClusterConfig) (string, error) {
+	conf := extractRedisConfig(cc)
+	r := cc.GetResources()
+
+	out := map[string]interface{}{
+		"version":                 conf.version,
+		"maxmemory_policy":        conf.maxmemoryPolicy,
+		"timeout":                 conf.timeout,
+		"notify_keyspace_events":  conf.notifyKeyspaceEvents,
+		"slowlog_log_slower_than": conf.slowlogLogSlowerThan,
+		"slowlog_max_len":         conf.slowlogMaxLen,
+		"databases":               conf.databases,
+		"resources": map[string]interface{}{
+			"resource_preset_id": r.GetResourcePresetId(),
+			"disk_size":          toGigabytes(r.GetDiskSize()),
+			"disk_type_id":       r.GetDiskTypeId(),
+		},
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("Error while marshaling Redis effective config to JSON: %s", err)
+	}
+
+	return string(b), nil
+}
+
+// Returns the CA certificate fetched once per provider. Failed fetches are not cached, so that a transient error
+// doesn't leave the certificate unknown until the provider is restarted.
+func getRedisTLSCACert(ctx context.Context, config *Config) (string, error) {
+	cache := redisProviderCacheOf(config)
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cache.tlsCACert != nil {
+		return *cache.tlsCACert, nil
+	}
+
+	cert, err := fetchRedisTLSCACert(ctx, redisTLSCACertURL)
+	if err != nil {
+		return "", err
+	}
+	cache.tlsCACert = &cert
+	return cert, nil
+}
+
+func fetchRedisTLSCACert(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisTLSCACertFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("Error while creating request for Redis TLS CA certificate: %s", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Error while getting Redis TLS CA certificate from %s: %s", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Error while getting Redis TLS CA certificate from %s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Error while reading Redis TLS CA certificate from %s: %s", url, err)
+	}
+
+	if block, _ := pem.Decode(body); block == nil || block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("Error while reading Redis TLS CA certificate from %s: no PEM encoded certificate found", url)
+	}
+
+	return string(body), nil
+}
+
+// Unset settings are left to the API defaults. A setting of an existing cluster changed to 0 is sent as 0 though,
+// as its path is in the update mask and a missing value would reset it to the default, see changedRedisConfigFields.
+func expandRedisConfigInt64(d *schema.ResourceData, key string) *wrappers.Int64Value {
+	v, ok := d.GetOk(key)
+	if !ok && (d.Id() == "" || !d.HasChange(key)) {
+		return nil
+	}
+	return &wrappers.Int64Value{Value: int64(v.(int))}
+}
+
 func expandRedisConfig(d *schema.ResourceData) (*redis.ConfigSpec_RedisSpec, string, error) {
 	var cs redis.ConfigSpec_RedisSpec
 
@@ -101,7 +388,7 @@ func expandRedisConfig(d *schema.ResourceData) (*redis.ConfigSpec_RedisSpec, str
 	}
 
 	var timeout *wrappers.Int64Value
-	if v, ok := d.GetOk("config.0.timeout"); ok {
+	if v, ok := d.GetOkExists("config.0.timeout"); ok {
 		timeout = &wrappers.Int64Value{Value: int64(v.(int))}
 	}
 
@@ -110,20 +397,11 @@ func expandRedisConfig(d *schema.ResourceData) (*redis.ConfigSpec_RedisSpec, str
 		notifyKeyspaceEvents = v.(string)
 	}
 
-	var slowlogLogSlowerThan *wrappers.Int64Value
-	if v, ok := d.GetOk("config.0.slowlog_log_slower_than"); ok {
-		slowlogLogSlowerThan = &wrappers.Int64Value{Value: int64(v.(int))}
-	}
+	slowlogLogSlowerThan := expandRedisConfigInt64(d, "config.0.slowlog_log_slower_than")
 
-	var slowlogMaxLen *wrappers.Int64Value
-	if v, ok := d.GetOk("config.0.slowlog_max_len"); ok {
-		slowlogMaxLen = &wrappers.Int64Value{Value: int64(v.(int))}
-	}
+	slowlogMaxLen := expandRedisConfigInt64(d, "config.0.slowlog_max_len")
 
-	var databases *wrappers.Int64Value
-	if v, ok := d.GetOk("config.0.databases"); ok {
-		databases = &wrappers.Int64Value{Value: int64(v.(int))}
-	}
+	databases := expandRedisConfigInt64(d, "config.0.databases")
 
 	var version string
 	if v, ok := d.GetOk("config.0.version"); ok {
@@ -192,6 +470,11 @@ func setMaxMemory6_0(c *config.RedisConfig6_0, d *schema.ResourceData) error {
 func flattenRedisResources(r *redis.Resources) ([]map[string]interface{}, error) {
 	res := map[string]interface{}{}
 
+	// disk_size is in whole gigabytes, a size that isn't would be truncated and show up as a diff on every plan
+	if r.DiskSize%toBytes(1) != 0 {
+		return nil, fmt.Errorf("Disk size of Redis hosts is %d bytes, which is not a whole number of gigabytes", r.DiskSize)
+	}
+
 	res["resource_preset_id"] = r.ResourcePresetId
 	res["disk_size"] = toGigabytes(r.DiskSize)
 	res["disk_type_id"] = r.DiskTypeId
@@ -217,6 +500,612 @@ func expandRedisResources(d *schema.ResourceData) (*redis.Resources, error) {
 	return rs, nil
 }
 
+// Disks of MDB hosts can only grow, so reject shrinking at plan time rather than failing in the middle of apply.
+func redisDiskSizeDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if rdiff.Id() == "" {
+		return nil
+	}
+
+	o, n := rdiff.GetChange("resources.0.disk_size")
+	oldSize, newSize := o.(int), n.(int)
+	if newSize < oldSize {
+		return fmt.Errorf("Decreasing disk_size of Redis Cluster %q is not supported: current size is %d GB, requested %d GB",
+			rdiff.Id(), oldSize, newSize)
+	}
+	return nil
+}
+
+// Changing environment recreates the cluster with all its data, so it must be allowed explicitly
+// rather than slip through in a large plan.
+func redisEnvironmentDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if rdiff.Id() == "" || !rdiff.HasChange("environment") || rdiff.Get("allow_environment_change").(bool) {
+		return nil
+	}
+
+	o, n := rdiff.GetChange("environment")
+	return fmt.Errorf("Changing environment of Redis Cluster %q from %s to %s recreates the cluster and loses all its data. "+
+		"Set allow_environment_change = true to do it anyway", rdiff.Get("name").(string), o, n)
+}
+
+// Sharded clusters run in Redis Cluster mode, which supports only database 0.
+// Only a new or changed value is checked: the API reports the default number of databases for sharded clusters too.
+// A cluster becoming sharded is re-created, so it is checked as a new one: the diff of a re-created resource
+// is customized once more without the state, only with the configured value of databases.
+func redisDatabasesDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if !rdiff.Get("sharded").(bool) || rdiff.Id() != "" && !rdiff.HasChange("config.0.databases") {
+		return nil
+	}
+
+	if databases := rdiff.Get("config.0.databases").(int); databases > 1 {
+		return fmt.Errorf("Redis Cluster %q is sharded and supports only a single database, but config.0.databases is %d",
+			rdiff.Get("name").(string), databases)
+	}
+	return nil
+}
+
+// Settings of the config block, each of them is a field with the same name in the Redis config of every version.
+var redisConfigUpdateFields = []string{"password", "timeout", "maxmemory_policy", "notify_keyspace_events", "slowlog_log_slower_than", "slowlog_max_len", "databases"}
+
+// Returns the changed settings of config to update.
+func changedRedisConfigFields(d *schema.ResourceData) []string {
+	fields := []string{}
+	for _, field := range redisConfigUpdateFields {
+		if d.HasChange("config.0." + field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Clears all settings of the Redis config in the spec except for the given ones.
+func keepRedisConfigFields(cs *redis.ConfigSpec, fields []string) {
+	var c protoreflect.ProtoMessage
+	switch spec := cs.RedisSpec.(type) {
+	case *redis.ConfigSpec_RedisConfig_5_0:
+		c = spec.RedisConfig_5_0
+	case *redis.ConfigSpec_RedisConfig_6_0:
+		c = spec.RedisConfig_6_0
+	default:
+		return
+	}
+
+	keep := map[protoreflect.Name]bool{}
+	for _, field := range fields {
+		keep[protoreflect.Name(field)] = true
+	}
+
+	m := c.ProtoReflect()
+	var clear []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !keep[fd.Name()] {
+			clear = append(clear, fd)
+		}
+		return true
+	})
+	for _, fd := range clear {
+		m.Clear(fd)
+	}
+}
+
+// Settings of the config block supported by every Redis version known to the API, taken from the fields of the Redis
+// config of the version, so that they follow the API. Version and password are accepted by all versions.
+var redisVersionConfigKeys = map[string][]string{
+	"5.0": redisConfigKeys(&config.RedisConfig5_0{}),
+	"6.0": redisConfigKeys(&config.RedisConfig6_0{}),
+}
+
+// Returns the settings of the config block which are fields of the Redis config c.
+func redisConfigKeys(c protoreflect.ProtoMessage) []string {
+	fields := c.ProtoReflect().Descriptor().Fields()
+	keys := []string{}
+	for _, key := range redisConfigDriftFields {
+		if fields.ByName(protoreflect.Name(key)) != nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Checks on plan that the declared version is supported and all settings set in config are supported by it.
+// Settings are optional and computed, so only settings set on create or changed since are taken into account,
+// the values of other settings come from the API.
+func redisVersionConfigDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if !rdiff.NewValueKnown("config.0.version") {
+		return nil
+	}
+	if rdiff.Id() != "" && !rdiff.HasChange("config") {
+		return nil
+	}
+
+	version := rdiff.Get("config.0.version").(string)
+	keys, ok := redisVersionConfigKeys[version]
+	if !ok {
+		return fmt.Errorf("config.0.version %q of Redis Cluster %q is not supported, supported versions are: %s",
+			version, rdiff.Get("name").(string), getJoinedKeys(redisSupportedVersions()))
+	}
+
+	supported := map[string]bool{}
+	for _, key := range keys {
+		supported[key] = true
+	}
+
+	var unsupported []string
+	for _, key := range redisConfigDriftFields {
+		if supported[key] {
+			continue
+		}
+		if _, ok := rdiff.GetOk("config.0." + key); ok && (rdiff.Id() == "" || rdiff.HasChange("config.0."+key)) {
+			unsupported = append(unsupported, key)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("settings %s of Redis Cluster %q are not supported by Redis %s",
+			strings.Join(unsupported, ", "), rdiff.Get("name").(string), version)
+	}
+	return nil
+}
+
+func redisSupportedVersions() []string {
+	versions := make([]string, 0, len(redisVersionConfigKeys))
+	for v := range redisVersionConfigKeys {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Optional and computed settings of config, the API may change their defaults without any change in Terraform.
+var redisConfigDriftFields = []string{"timeout", "maxmemory_policy", "notify_keyspace_events", "slowlog_log_slower_than", "slowlog_max_len", "databases"}
+
+// Returns the current values of the settings which differ from the ones in the state, i.e. changed outside
+// of Terraform since the previous read. The config read from the API is set to the state as is, so the drift
+// of the settings which are not declared in the configuration is visible only in config_drift.
+// There is no previous read right after import, so there is no drift either.
+func redisConfigDrift(d *schema.ResourceData, conf map[string]interface{}) map[string]string {
+	drift := map[string]string{}
+	if len(d.Get("config").([]interface{})) == 0 {
+		return drift
+	}
+	for _, field := range redisConfigDriftFields {
+		known := d.Get("config.0." + field)
+		if current := fmt.Sprint(conf[field]); current != fmt.Sprint(known) {
+			drift[field] = current
+		}
+	}
+	return drift
+}
+
+// Config drift is shown in the plan as its removal, applying it only acknowledges the drift. Settings declared
+// in the configuration have their own diff in config, and applying it restores them.
+func redisConfigDriftDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if rdiff.Id() == "" || len(rdiff.Get("config_drift").(map[string]interface{})) == 0 {
+		return nil
+	}
+	return rdiff.SetNew("config_drift", map[string]interface{}{})
+}
+
+// TLS can't be enabled or disabled on an existing cluster, the API only accepts it on create.
+// So changing tls_enabled recreates the cluster with all its data, which the plan explains with a warning.
+func redisTLSDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if rdiff.Id() == "" || !rdiff.HasChange("tls_enabled") {
+		return nil
+	}
+
+	o, n := rdiff.GetChange("tls_enabled")
+	log.Printf("[WARN] %s", redisTLSChangeWarning(rdiff.Get("name").(string), o.(bool), n.(bool)))
+	return nil
+}
+
+func redisTLSChangeWarning(clusterName string, oldTLS, newTLS bool) string {
+	return fmt.Sprintf("Changing tls_enabled of Redis Cluster %q from %t to %t is not supported by Yandex Cloud for existing clusters, "+
+		"so it recreates the cluster and loses all its data", clusterName, oldTLS, newTLS)
+}
+
+// A cluster without password is open to anyone in its network, so it needs TLS or an explicit acknowledgment.
+// tls_enabled is computed, so it is unknown on create unless it is set, which means TLS is disabled.
+func redisPasswordDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if !rdiff.NewValueKnown("config.0.password") {
+		return nil
+	}
+	if rdiff.Get("config.0.password").(string) != "" || rdiff.Get("tls_enabled").(bool) || rdiff.Get("allow_no_password").(bool) {
+		return nil
+	}
+	return fmt.Errorf("Redis Cluster %q has no password, enable TLS with tls_enabled = true or set allow_no_password = true",
+		rdiff.Get("name").(string))
+}
+
+// Support needs the request ID to look into a failure. Errors of API calls already have it in their message,
+// while failed operations only carry it in the details of their status.
+func redisErrorWithRequestID(err error) error {
+	reqID, ok := isRequestIDPresent(err)
+	if !ok || reqID == "" || strings.Contains(err.Error(), reqID) {
+		return err
+	}
+	return fmt.Errorf("%s (request ID: %s)", err, reqID)
+}
+
+// Labels with this prefix are set by the cloud itself, not by users.
+const mdbSystemLabelPrefix = "yandex.cloud/"
+
+func isMDBSystemLabel(key string) bool {
+	return strings.HasPrefix(key, mdbSystemLabelPrefix)
+}
+
+// Drops system labels from the labels returned by API unless they are declared in the configuration,
+// otherwise they show up as a perpetual diff.
+func filterMDBSystemLabels(labels map[string]string, declared map[string]interface{}) map[string]string {
+	res := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if _, ok := declared[k]; ok || !isMDBSystemLabel(k) {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+const redisNearestResourcePresetsCount = 3
+
+// Redis resource presets are listed at most once per provider, see Config.redisCache.
+func getRedisResourcePresets(config *Config) ([]*redis.ResourcePreset, error) {
+	cache := redisProviderCacheOf(config)
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cache.resourcePresets != nil {
+		return cache.resourcePresets, nil
+	}
+
+	// provider is not configured, e.g. offline `terraform validate`
+	if config.sdk == nil {
+		return nil, nil
+	}
+
+	presets, err := config.sdk.MDB().Redis().ResourcePreset().ResourcePresetIterator(config.Context(), &redis.ListResourcePresetsRequest{
+		PageSize: defaultMDBPageSize,
+	}).TakeAll()
+	if err != nil {
+		return nil, fmt.Errorf("Error while listing Redis resource presets: %s", err)
+	}
+
+	cache.resourcePresets = presets
+	return presets, nil
+}
+
+func listRedisResourcePresets(config *Config) ([]string, error) {
+	presets, err := getRedisResourcePresets(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(presets))
+	for _, p := range presets {
+		ids = append(ids, p.Id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func redisResourcePresetDiffCustomize(rdiff *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || config == nil {
+		return nil
+	}
+
+	key := "resources.0.resource_preset_id"
+	if !rdiff.HasChange(key) || !rdiff.NewValueKnown(key) {
+		return nil
+	}
+
+	presets, err := listRedisResourcePresets(config)
+	if err != nil {
+		log.Printf("[WARN] Skipping validation of Redis resource_preset_id: %s", err)
+		return nil
+	}
+	if len(presets) == 0 {
+		return nil
+	}
+
+	presetID := rdiff.Get(key).(string)
+	for _, p := range presets {
+		if p == presetID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown Redis resource_preset_id %q, the nearest valid presets are: %s",
+		presetID, strings.Join(nearestStrings(presetID, presets, redisNearestResourcePresetsCount), ", "))
+}
+
+func redisResourcePresetFamilyDiffCustomize(rdiff *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || config == nil {
+		return nil
+	}
+
+	key := "resources.0.resource_preset_id"
+	if rdiff.Id() == "" || !rdiff.HasChange(key) || !rdiff.NewValueKnown(key) {
+		return nil
+	}
+
+	presets, err := getRedisResourcePresets(config)
+	if err != nil {
+		log.Printf("[WARN] Skipping family check of Redis resource_preset_id: %s", err)
+		return nil
+	}
+
+	o, n := rdiff.GetChange(key)
+	oldPreset, newPreset := findRedisResourcePreset(presets, o.(string)), findRedisResourcePreset(presets, n.(string))
+	if w := redisResourcePresetFamilyWarning(rdiff.Get("name").(string), oldPreset, newPreset); w != "" {
+		log.Printf("[WARN] %s", w)
+	}
+	return nil
+}
+
+func findRedisResourcePreset(presets []*redis.ResourcePreset, presetID string) *redis.ResourcePreset {
+	for _, p := range presets {
+		if p.Id == presetID {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns the warning about the change of the preset family, e.g. from burstable "b2.nano" to "hm1.nano",
+// the hosts are moved to the other hardware then and it is not an ordinary in-place resize.
+// Preset names are not reliable on their own, so the warning is given only when the memory per core
+// of the presets differs as well. Presets that are not known give no warning.
+func redisResourcePresetFamilyWarning(clusterName string, oldPreset, newPreset *redis.ResourcePreset) string {
+	if oldPreset == nil || newPreset == nil || oldPreset.Cores == 0 || newPreset.Cores == 0 {
+		return ""
+	}
+	oldFamily, newFamily := redisResourcePresetFamily(oldPreset.Id), redisResourcePresetFamily(newPreset.Id)
+	if oldFamily == newFamily || oldPreset.Memory*newPreset.Cores == newPreset.Memory*oldPreset.Cores {
+		return ""
+	}
+	return fmt.Sprintf("Resource preset of Redis Cluster %q is changed from %q to %q of the other family %q "+
+		"(%g GB instead of %g GB of memory per core), it may take extended downtime to move the hosts",
+		clusterName, oldPreset.Id, newPreset.Id, newFamily,
+		toGigabytesInFloat(newPreset.Memory)/float64(newPreset.Cores), toGigabytesInFloat(oldPreset.Memory)/float64(oldPreset.Cores))
+}
+
+// Family is the prefix of the preset ID, e.g. "hm1" of "hm1.nano" and "hm3" of "hm3-c2-m8".
+func redisResourcePresetFamily(presetID string) string {
+	if i := strings.IndexAny(presetID, ".-"); i > 0 {
+		return presetID[:i]
+	}
+	return presetID
+}
+
+// Flags accepted by notify-keyspace-events option of Redis.
+const redisNotifyKeyspaceEventsFlags = "KEg$lshzxetmndA"
+
+func validateRedisNotifyKeyspaceEvents(v interface{}, k string) (ws []string, errs []error) {
+	value := v.(string)
+	for _, c := range value {
+		if !strings.ContainsRune(redisNotifyKeyspaceEventsFlags, c) {
+			errs = append(errs, fmt.Errorf("%q contains invalid flag %q, valid flags are %s", k, c,
+				strings.Join(strings.Split(redisNotifyKeyspaceEventsFlags, ""), ", ")))
+			return
+		}
+	}
+	return
+}
+
+// Length limits of the password accepted by the API. They are not a policy of the provider, so they follow the API
+// in new provider releases rather than being configurable.
+const (
+	redisPasswordMinLength = 8
+	redisPasswordMaxLength = 128
+)
+
+// An empty password means the cluster has no password at all, see allow_no_password.
+func validateRedisPassword(v interface{}, k string) (ws []string, errs []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+	if len(value) < redisPasswordMinLength || len(value) > redisPasswordMaxLength {
+		errs = append(errs, fmt.Errorf("%q must be from %d to %d characters long, got %d characters", k,
+			redisPasswordMinLength, redisPasswordMaxLength, len(value)))
+	}
+	if strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		errs = append(errs, fmt.Errorf("%q must not contain whitespace", k))
+	}
+	return
+}
+
+var redisDiskTypes = []string{"network-ssd", "network-hdd", "network-ssd-nonreplicated", "local-ssd"}
+
+// An even number of hosts in a shard can't form a majority on failover. Some setups want it anyway,
+// so it is only a warning in the log rather than an error.
+func redisShardHostsDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if !rdiff.HasChange("host") || !rdiff.Get("sharded").(bool) {
+		return nil
+	}
+
+	for _, w := range redisEvenShardHostsWarnings(rdiff.Get("name").(string), rdiff.Get("host").([]interface{})) {
+		log.Printf("[WARN] %s", w)
+	}
+	return nil
+}
+
+func redisEvenShardHostsWarnings(clusterName string, hosts []interface{}) []string {
+	counts := map[string]int{}
+	for _, h := range hosts {
+		if shardName := h.(map[string]interface{})["shard_name"].(string); shardName != "" {
+			counts[shardName]++
+		}
+	}
+
+	shardNames := make([]string, 0, len(counts))
+	for name := range counts {
+		shardNames = append(shardNames, name)
+	}
+	sort.Strings(shardNames)
+
+	warnings := []string{}
+	for _, name := range shardNames {
+		if counts[name]%2 == 0 {
+			warnings = append(warnings, fmt.Sprintf("Shard %q of Redis Cluster %q has %d hosts, "+
+				"an odd number of hosts per shard is recommended for quorum on failover", name, clusterName, counts[name]))
+		}
+	}
+	return warnings
+}
+
+// A shard scaled down to a single host has no replicas left, which is often accidental. It is a warning in the log
+// unless require_host_redundancy is set.
+func redisHostRedundancyDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if rdiff.Id() == "" || !rdiff.HasChange("host") {
+		return nil
+	}
+
+	o, n := rdiff.GetChange("host")
+	warnings := redisSingleHostShardsWarnings(rdiff.Get("name").(string), o.([]interface{}), n.([]interface{}), rdiff.Get("sharded").(bool))
+	if len(warnings) == 0 {
+		return nil
+	}
+	if rdiff.Get("require_host_redundancy").(bool) {
+		return fmt.Errorf("%s. Unset require_host_redundancy to do it anyway", strings.Join(warnings, ". "))
+	}
+	for _, w := range warnings {
+		log.Printf("[WARN] %s", w)
+	}
+	return nil
+}
+
+func redisSingleHostShardsWarnings(clusterName string, oldHosts, newHosts []interface{}, sharded bool) []string {
+	// hosts of a non-sharded cluster all belong to a single shard
+	countHosts := func(hosts []interface{}) map[string]int {
+		counts := map[string]int{}
+		for _, h := range hosts {
+			shardName := ""
+			if sharded {
+				shardName = h.(map[string]interface{})["shard_name"].(string)
+			}
+			counts[shardName]++
+		}
+		return counts
+	}
+	oldCounts, newCounts := countHosts(oldHosts), countHosts(newHosts)
+
+	shardNames := make([]string, 0, len(newCounts))
+	for name := range newCounts {
+		shardNames = append(shardNames, name)
+	}
+	sort.Strings(shardNames)
+
+	warnings := []string{}
+	for _, name := range shardNames {
+		if newCounts[name] != 1 || oldCounts[name] < 2 {
+			continue
+		}
+		if sharded {
+			warnings = append(warnings, fmt.Sprintf("Shard %q of Redis Cluster %q is scaled down from %d hosts to a single host "+
+				"and has no replicas left", name, clusterName, oldCounts[name]))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("Redis Cluster %q is scaled down from %d hosts to a single host "+
+				"and has no replicas left", clusterName, oldCounts[name]))
+		}
+	}
+	return warnings
+}
+
+// Hosts can only be placed in subnets of the cluster network, otherwise adding them fails late in apply.
+// Subnets are resolved via API, so the check is skipped when the provider is not configured, e.g. offline.
+func redisHostSubnetsDiffCustomize(rdiff *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || config == nil || config.sdk == nil {
+		return nil
+	}
+	if !rdiff.HasChange("host") && !rdiff.HasChange("network_id") || !rdiff.NewValueKnown("network_id") {
+		return nil
+	}
+
+	subnetIDs := []string{}
+	for i, h := range rdiff.Get("host").([]interface{}) {
+		if !rdiff.NewValueKnown(fmt.Sprintf("host.%d.subnet_id", i)) {
+			continue
+		}
+		if id := h.(map[string]interface{})["subnet_id"].(string); id != "" {
+			subnetIDs = append(subnetIDs, id)
+		}
+	}
+
+	return checkRedisHostSubnets(rdiff.Get("network_id").(string), subnetIDs, func(subnetID string) (string, error) {
+		subnet, err := config.sdk.VPC().Subnet().Get(config.Context(), &vpc.GetSubnetRequest{
+			SubnetId: subnetID,
+		})
+		if err != nil {
+			return "", err
+		}
+		return subnet.NetworkId, nil
+	})
+}
+
+func checkRedisHostSubnets(networkID string, subnetIDs []string, subnetNetworkID func(subnetID string) (string, error)) error {
+	checked := map[string]bool{}
+	for _, id := range subnetIDs {
+		if checked[id] {
+			continue
+		}
+		checked[id] = true
+
+		subnetNetwork, err := subnetNetworkID(id)
+		if err != nil {
+			log.Printf("[WARN] Skipping validation of Redis host subnet %q: %s", id, err)
+			continue
+		}
+		if subnetNetwork != networkID {
+			return fmt.Errorf("Subnet %q of Redis host belongs to network %q, not to the cluster network %q", id, subnetNetwork, networkID)
+		}
+	}
+	return nil
+}
+
+// There is no dry run of cluster creation in Redis API, so the zones of the hosts are checked against
+// the zones of the resource preset at plan time, which catches unknown zones as well.
+func redisHostZonesDiffCustomize(rdiff *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || config == nil {
+		return nil
+	}
+	presetKey := "resources.0.resource_preset_id"
+	if !rdiff.HasChange("host") && !rdiff.HasChange(presetKey) || !rdiff.NewValueKnown(presetKey) {
+		return nil
+	}
+
+	zones := []string{}
+	for i, h := range rdiff.Get("host").([]interface{}) {
+		if !rdiff.NewValueKnown(fmt.Sprintf("host.%d.zone", i)) {
+			continue
+		}
+		zones = append(zones, h.(map[string]interface{})["zone"].(string))
+	}
+
+	presetID := rdiff.Get(presetKey).(string)
+	if presetID == "" || len(zones) == 0 {
+		return nil
+	}
+
+	presets, err := getRedisResourcePresets(config)
+	if err != nil {
+		log.Printf("[WARN] Skipping validation of Redis host zones: %s", err)
+		return nil
+	}
+	// presets that can't be resolved are left to the resource preset check
+	preset := findRedisResourcePreset(presets, presetID)
+	if preset == nil {
+		return nil
+	}
+
+	if zone, ok := redisZoneWithoutPreset(preset, zones); ok {
+		return fmt.Errorf("Zone %q of Redis host is unknown or resource preset %q is not available in it, the preset is available in zones: %s",
+			zone, presetID, strings.Join(preset.ZoneIds, ", "))
+	}
+	return nil
+}
+
 func parseRedisWeekDay(wd string) (redis.WeeklyMaintenanceWindow_WeekDay, error) {
 	val, ok := redis.WeeklyMaintenanceWindow_WeekDay_value[wd]
 	// do not allow WEEK_DAY_UNSPECIFIED
@@ -239,6 +1128,8 @@ func expandRedisMaintenanceWindow(d *schema.ResourceData) (*redis.MaintenanceWin
 
 	switch mwType {
 	case "ANYTIME":
+		// day and hour of the former WEEKLY window are already empty in the planned value,
+		// so only the ones set in the config along with ANYTIME are rejected
 		timeSet := false
 		if _, ok := d.GetOk("maintenance_window.0.day"); ok {
 			timeSet = true
@@ -271,6 +1162,10 @@ func expandRedisMaintenanceWindow(d *schema.ResourceData) (*redis.MaintenanceWin
 }
 
 func flattenRedisMaintenanceWindow(mw *redis.MaintenanceWindow) []map[string]interface{} {
+	if mw == nil {
+		return []map[string]interface{}{}
+	}
+
 	result := map[string]interface{}{}
 
 	if val := mw.GetAnytime(); val != nil {
@@ -286,6 +1181,89 @@ func flattenRedisMaintenanceWindow(mw *redis.MaintenanceWindow) []map[string]int
 	return []map[string]interface{}{result}
 }
 
+// Returns nil if maintenance_reschedule is not set.
+func expandRedisMaintenanceReschedule(d *schema.ResourceData) (*redis.RescheduleMaintenanceRequest, error) {
+	if _, ok := d.GetOk("maintenance_reschedule"); !ok {
+		return nil, nil
+	}
+
+	rescheduleType := d.Get("maintenance_reschedule.0.type").(string)
+	v, ok := redis.RescheduleMaintenanceRequest_RescheduleType_value[rescheduleType]
+	if !ok {
+		return nil, fmt.Errorf("value for 'type' must be one of %s, not `%s`",
+			getJoinedKeys(getEnumValueMapKeys(redis.RescheduleMaintenanceRequest_RescheduleType_value)), rescheduleType)
+	}
+	req := &redis.RescheduleMaintenanceRequest{
+		ClusterId:      d.Id(),
+		RescheduleType: redis.RescheduleMaintenanceRequest_RescheduleType(v),
+	}
+
+	delayedUntil := d.Get("maintenance_reschedule.0.delayed_until").(string)
+	if delayedUntil == "" {
+		if req.RescheduleType == redis.RescheduleMaintenanceRequest_SPECIFIC_TIME {
+			return nil, fmt.Errorf("'delayed_until' must be set for SPECIFIC_TIME")
+		}
+		return req, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, delayedUntil)
+	if err != nil {
+		return nil, err
+	}
+	req.DelayedUntil, err = ptypes.TimestampProto(t)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// Clusters without scheduled maintenance have no planned operation, it is reported as an empty list.
+func flattenRedisPlannedOperation(op *redis.MaintenanceOperation) []map[string]interface{} {
+	if op == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"info":          op.Info,
+			"delayed_until": getTimestampOrEmpty(op.DelayedUntil, "planned_operation.0.delayed_until"),
+		},
+	}
+}
+
+// Counts hosts of every shard, shards are sorted by name. All hosts of a non-sharded cluster
+// belong to a single shard, whatever shard names they have.
+func flattenRedisShardHostCounts(hs []*redis.Host, sharded bool) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	if !sharded {
+		if len(hs) > 0 {
+			res = append(res, map[string]interface{}{
+				"name":       hs[0].ShardName,
+				"host_count": len(hs),
+			})
+		}
+		return res
+	}
+
+	counts := map[string]int{}
+	names := []string{}
+	for _, h := range hs {
+		if _, ok := counts[h.ShardName]; !ok {
+			names = append(names, h.ShardName)
+		}
+		counts[h.ShardName]++
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		res = append(res, map[string]interface{}{
+			"name":       name,
+			"host_count": counts[name],
+		})
+	}
+	return res
+}
+
 func flattenRedisHosts(hs []*redis.Host) ([]map[string]interface{}, error) {
 	res := []map[string]interface{}{}
 
@@ -295,12 +1273,67 @@ func flattenRedisHosts(hs []*redis.Host) ([]map[string]interface{}, error) {
 		m["subnet_id"] = h.SubnetId
 		m["shard_name"] = h.ShardName
 		m["fqdn"] = h.Name
+		m["role"] = h.GetRole().String()
+		m["health"] = h.GetHealth().String()
 		res = append(res, m)
 	}
 
 	return res, nil
 }
 
+// Lists FQDNs of the flattened hosts in the same order, so that they match the host list.
+func flattenRedisHostFQDNs(hs []map[string]interface{}) []string {
+	res := make([]string, 0, len(hs))
+	for _, h := range hs {
+		res = append(res, h["fqdn"].(string))
+	}
+	return res
+}
+
+// Splits the flattened hosts by their role. A non-sharded cluster has a single master, while a sharded one
+// has a master per shard, so its masters are returned by shard name and master is left empty.
+// Replicas are grouped by shard and keep the order of the host list within a shard, for a sharded cluster
+// they are returned per shard as well, as `shard_replica_fqdns` blocks.
+func flattenRedisHostEndpoints(hs []map[string]interface{}, sharded bool) (string, []string, map[string]string, []map[string]interface{}) {
+	master := ""
+	replicas := []string{}
+	shardMasters := map[string]string{}
+	shardReplicas := []map[string]interface{}{}
+
+	sorted := make([]map[string]interface{}, len(hs))
+	copy(sorted, hs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i]["shard_name"].(string) < sorted[j]["shard_name"].(string)
+	})
+
+	for _, h := range sorted {
+		fqdn := h["fqdn"].(string)
+		switch h["role"].(string) {
+		case redis.Host_MASTER.String():
+			if sharded {
+				shardMasters[h["shard_name"].(string)] = fqdn
+			} else {
+				master = fqdn
+			}
+		case redis.Host_REPLICA.String():
+			replicas = append(replicas, fqdn)
+			if !sharded {
+				continue
+			}
+			shardName := h["shard_name"].(string)
+			if n := len(shardReplicas); n == 0 || shardReplicas[n-1]["shard_name"] != shardName {
+				shardReplicas = append(shardReplicas, map[string]interface{}{
+					"shard_name": shardName,
+					"fqdns":      []string{},
+				})
+			}
+			last := shardReplicas[len(shardReplicas)-1]
+			last["fqdns"] = append(last["fqdns"].([]string), fqdn)
+		}
+	}
+	return master, replicas, shardMasters, shardReplicas
+}
+
 func expandRedisHosts(d *schema.ResourceData) ([]*redis.HostSpec, error) {
 	var result []*redis.HostSpec
 	hosts := d.Get("host").([]interface{})
@@ -0,0 +1,2040 @@
+package yandex
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+	config "github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1/config"
+	ycoperation "github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-sdk/operation"
+)
+
+func testRedisClusterRaw(diskSize int) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "redis-tf-name",
+		"network_id":  "net-777",
+		"environment": "PRESTABLE",
+		"config": []interface{}{
+			map[string]interface{}{
+				"password": "passw0rd",
+				"version":  "6.0",
+			},
+		},
+		"resources": []interface{}{
+			map[string]interface{}{
+				"resource_preset_id": "hm1.nano",
+				"disk_size":          diskSize,
+			},
+		},
+		"host": []interface{}{
+			map[string]interface{}{
+				"zone": "ru-central1-a",
+			},
+		},
+	}
+}
+
+// Computes the plan for the Redis cluster resource, running its CustomizeDiff.
+// The old configuration is turned into the state of an existing cluster, nil means the cluster is being created.
+func testRedisClusterDiff(t *testing.T, oldRaw, newRaw map[string]interface{}) (*terraform.InstanceDiff, error) {
+	return testRedisClusterDiffWithMeta(t, oldRaw, newRaw, nil)
+}
+
+var testRedisZones = []string{"ru-central1-a", "ru-central1-b", "ru-central1-c"}
+
+func testRedisClusterDiffWithMeta(t *testing.T, oldRaw, newRaw map[string]interface{}, meta interface{}) (*terraform.InstanceDiff, error) {
+	res := resourceYandexMDBRedisCluster()
+
+	var state *terraform.InstanceState
+	if oldRaw != nil {
+		d := schema.TestResourceDataRaw(t, res.Schema, oldRaw)
+		d.SetId("cid-777")
+		state = d.State()
+	}
+
+	return res.Diff(state, terraform.NewResourceConfigRaw(newRaw), meta)
+}
+
+func TestRedisDiskSizeDiffCustomize(t *testing.T) {
+	_, err := testRedisClusterDiff(t, nil, testRedisClusterRaw(16))
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiff(t, testRedisClusterRaw(16), testRedisClusterRaw(24))
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiff(t, testRedisClusterRaw(24), testRedisClusterRaw(16))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "current size is 24 GB, requested 16 GB")
+}
+
+func TestRedisResourcesDiskSizeRoundTrip(t *testing.T) {
+	for _, size := range []int{16, 100} {
+		d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, testRedisClusterRaw(size))
+
+		rs, err := expandRedisResources(d)
+		require.NoError(t, err)
+		require.Equal(t, int64(size)<<30, rs.DiskSize)
+
+		flat, err := flattenRedisResources(rs)
+		require.NoError(t, err)
+		require.Equal(t, size, flat[0]["disk_size"])
+	}
+
+	_, err := flattenRedisResources(&redis.Resources{ResourcePresetId: "hm1.nano", DiskSize: 16<<30 + 512})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not a whole number of gigabytes")
+}
+
+func TestRedisZoneWithoutPreset(t *testing.T) {
+	preset := &redis.ResourcePreset{Id: "hm1.large", ZoneIds: []string{"ru-central1-a", "ru-central1-b"}}
+
+	_, ok := redisZoneWithoutPreset(preset, []string{"ru-central1-a", "ru-central1-b"})
+	require.False(t, ok)
+
+	zone, ok := redisZoneWithoutPreset(preset, []string{"ru-central1-a", "ru-central1-c", "ru-central1-b"})
+	require.True(t, ok)
+	require.Equal(t, "ru-central1-c", zone)
+}
+
+// Builds the resource data of an existing cluster being updated from oldRaw to newRaw configuration.
+func testRedisClusterUpdateData(t *testing.T, oldRaw, newRaw map[string]interface{}) *schema.ResourceData {
+	res := resourceYandexMDBRedisCluster()
+
+	state := schema.TestResourceDataRaw(t, res.Schema, oldRaw)
+	state.SetId("cid-777")
+
+	diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(newRaw), nil)
+	require.NoError(t, err)
+	d, err := schema.InternalMap(res.Schema).Data(state.State(), diff)
+	require.NoError(t, err)
+	return d
+}
+
+func TestRedisClusterConfigPartialUpdate(t *testing.T) {
+	withConfig := func(settings map[string]interface{}) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		conf := raw["config"].([]interface{})[0].(map[string]interface{})
+		conf["timeout"] = 100
+		conf["slowlog_max_len"] = 10
+		conf["notify_keyspace_events"] = "Elg"
+		for k, v := range settings {
+			conf[k] = v
+		}
+		return raw
+	}
+
+	d := testRedisClusterUpdateData(t, withConfig(map[string]interface{}{"maxmemory_policy": "NOEVICTION"}),
+		withConfig(map[string]interface{}{"maxmemory_policy": "ALLKEYS_LRU"}))
+	req, _, err := prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"config_spec.redis_config_6_0.maxmemory_policy"}, req.UpdateMask.Paths)
+
+	conf := req.ConfigSpec.GetRedisConfig_6_0()
+	require.Equal(t, config.RedisConfig6_0_ALLKEYS_LRU, conf.MaxmemoryPolicy)
+	require.Empty(t, conf.Password)
+	require.Nil(t, conf.Timeout)
+	require.Nil(t, conf.SlowlogMaxLen)
+	require.Empty(t, conf.NotifyKeyspaceEvents)
+
+	d = testRedisClusterUpdateData(t, withConfig(nil), withConfig(map[string]interface{}{"password": "new-passw0rd", "timeout": 200}))
+	req, _, err = prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"config_spec.redis_config_6_0.password", "config_spec.redis_config_6_0.timeout"}, req.UpdateMask.Paths)
+	require.Equal(t, "new-passw0rd", req.ConfigSpec.GetRedisConfig_6_0().Password)
+	require.Equal(t, int64(200), req.ConfigSpec.GetRedisConfig_6_0().GetTimeout().GetValue())
+	require.Nil(t, req.ConfigSpec.GetRedisConfig_6_0().SlowlogMaxLen)
+
+	// a setting changed to 0 is sent as 0, not reset to the API default
+	d = testRedisClusterUpdateData(t, withConfig(map[string]interface{}{"slowlog_log_slower_than": 100}),
+		withConfig(map[string]interface{}{"slowlog_max_len": 0, "slowlog_log_slower_than": 0}))
+	req, _, err = prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"config_spec.redis_config_6_0.slowlog_log_slower_than", "config_spec.redis_config_6_0.slowlog_max_len"}, req.UpdateMask.Paths)
+	require.NotNil(t, req.ConfigSpec.GetRedisConfig_6_0().GetSlowlogMaxLen())
+	require.Equal(t, int64(0), req.ConfigSpec.GetRedisConfig_6_0().GetSlowlogMaxLen().GetValue())
+	require.NotNil(t, req.ConfigSpec.GetRedisConfig_6_0().GetSlowlogLogSlowerThan())
+	require.Nil(t, req.ConfigSpec.GetRedisConfig_6_0().Databases)
+}
+
+func TestRedisClusterDescriptionSetAndClear(t *testing.T) {
+	withDescription := func(description string) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["description"] = description
+		return raw
+	}
+
+	d := testRedisClusterUpdateData(t, testRedisClusterRaw(16), withDescription("first line\nsecond line"))
+	req, _, err := prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"description"}, req.UpdateMask.Paths)
+	require.Equal(t, "first line\nsecond line", req.Description)
+
+	for _, newRaw := range []map[string]interface{}{withDescription(""), testRedisClusterRaw(16)} {
+		d = testRedisClusterUpdateData(t, withDescription("first line\nsecond line"), newRaw)
+		req, _, err = prepareUpdateRedisClusterParamsRequest(d, &Config{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"description"}, req.UpdateMask.Paths)
+		require.Equal(t, "", req.Description)
+	}
+
+	// empty description read back from the API is the same as no description at all
+	for _, newRaw := range []map[string]interface{}{withDescription(""), testRedisClusterRaw(16)} {
+		d = testRedisClusterUpdateData(t, withDescription(""), newRaw)
+		require.False(t, d.HasChange("description"))
+	}
+}
+
+func TestRedisClusterLabelsOnlyUpdate(t *testing.T) {
+	withLabels := func(labels map[string]interface{}) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["labels"] = labels
+		return raw
+	}
+
+	d := testRedisClusterUpdateData(t, withLabels(map[string]interface{}{"env": "test"}),
+		withLabels(map[string]interface{}{"env": "prod", "team": "cache"}))
+	req, _, err := prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"labels"}, req.UpdateMask.Paths)
+	require.Equal(t, map[string]string{"env": "prod", "team": "cache"}, req.Labels)
+	require.Nil(t, req.ConfigSpec)
+
+	// system labels of the cluster are kept
+	refreshRedisUpdateRequest(req, &redis.Cluster{Labels: map[string]string{"yandex.cloud/system": "yes", "env": "test"}},
+		map[string]interface{}{"env": "test"})
+	require.Equal(t, map[string]string{"env": "prod", "team": "cache", "yandex.cloud/system": "yes"}, req.Labels)
+	require.Nil(t, req.ConfigSpec)
+
+	// a system label removed from the configuration is not added back
+	d = testRedisClusterUpdateData(t, withLabels(map[string]interface{}{"env": "test", "yandex.cloud/system": "yes"}),
+		withLabels(map[string]interface{}{"env": "prod"}))
+	req, _, err = prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	oldLabels, _ := d.GetChange("labels")
+	refreshRedisUpdateRequest(req, &redis.Cluster{Labels: map[string]string{"yandex.cloud/system": "yes", "yandex.cloud/other": "yes"}},
+		oldLabels.(map[string]interface{}))
+	require.Equal(t, map[string]string{"env": "prod", "yandex.cloud/other": "yes"}, req.Labels)
+}
+
+func TestRedisClusterSecurityGroupsClear(t *testing.T) {
+	withSecurityGroups := func(ids ...interface{}) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["security_group_ids"] = ids
+		return raw
+	}
+
+	for _, newRaw := range []map[string]interface{}{withSecurityGroups(), testRedisClusterRaw(16)} {
+		d := testRedisClusterUpdateData(t, withSecurityGroups("sg-1", "sg-2"), newRaw)
+		req, _, err := prepareUpdateRedisClusterParamsRequest(d, &Config{})
+		require.NoError(t, err)
+		// the groups are cleared by the path in the update mask, an empty list is the same as none on the wire
+		require.Equal(t, []string{"security_group_ids"}, req.UpdateMask.Paths)
+		require.Empty(t, req.SecurityGroupIds)
+	}
+
+	// no groups read back from the API are the same as the cleared ones
+	d := testRedisClusterUpdateData(t, withSecurityGroups(), testRedisClusterRaw(16))
+	require.NoError(t, d.Set("security_group_ids", flattenSecurityGroupIds(nil)))
+	require.False(t, d.HasChange("security_group_ids"))
+	require.Equal(t, 0, d.Get("security_group_ids").(*schema.Set).Len())
+}
+
+func TestRedisClusterErrorsContainClusterID(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	raw["environment"] = "TESTING"
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	_, err := prepareCreateRedisRequest(d, &Config{FolderID: "folder-777"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"environment" on create of Redis Cluster "redis-tf-name"`)
+
+	newRaw := testRedisClusterRaw(16)
+	newRaw["config"] = []interface{}{
+		map[string]interface{}{
+			"password": "passw0rd",
+			"version":  "5.0",
+		},
+	}
+	err = updateRedisClusterParams(testRedisClusterUpdateData(t, testRedisClusterRaw(16), newRaw), &Config{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Changing "config.0.version" of Redis Cluster "cid-777"`)
+
+	newRaw = testRedisClusterRaw(16)
+	newRaw["resources"].([]interface{})[0].(map[string]interface{})["disk_type_id"] = "network-hdd"
+	err = resourceYandexMDBRedisClusterUpdate(testRedisClusterUpdateData(t, testRedisClusterRaw(16), newRaw), &Config{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Changing "resources.0.disk_type_id" of Redis Cluster "cid-777"`)
+}
+
+func TestRedisPasswordDiffCustomize(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	raw["config"] = []interface{}{
+		map[string]interface{}{
+			"version": "6.0",
+		},
+	}
+
+	_, err := testRedisClusterDiff(t, nil, raw)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Redis Cluster "redis-tf-name" has no password`)
+
+	raw["tls_enabled"] = true
+	_, err = testRedisClusterDiff(t, nil, raw)
+	require.NoError(t, err)
+
+	delete(raw, "tls_enabled")
+	raw["allow_no_password"] = true
+	_, err = testRedisClusterDiff(t, nil, raw)
+	require.NoError(t, err)
+
+	// the cluster is created without AUTH
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	req, err := prepareCreateRedisRequest(d, &Config{FolderID: "folder-777"})
+	require.NoError(t, err)
+	require.Empty(t, req.ConfigSpec.GetRedisConfig_6_0().GetPassword())
+}
+
+func TestPrepareRestoreRedisRequestIntoNewNetwork(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	raw["network_id"] = "net-new"
+	raw["security_group_ids"] = []interface{}{"sg-new"}
+	raw["host"] = []interface{}{
+		map[string]interface{}{"zone": "ru-central1-a", "subnet_id": "subnet-new-a"},
+		map[string]interface{}{"zone": "ru-central1-b", "subnet_id": "subnet-new-b"},
+	}
+	raw["restore"] = []interface{}{
+		map[string]interface{}{"backup_id": "backup-777"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	req, err := prepareCreateRedisRequest(d, &Config{FolderID: "folder-777"})
+	require.NoError(t, err)
+
+	restoreReq := prepareRestoreRedisRequest(req, d.Get("restore.0.backup_id").(string))
+	require.Equal(t, "backup-777", restoreReq.BackupId)
+	require.Equal(t, "redis-tf-name", restoreReq.Name)
+	require.Equal(t, "folder-777", restoreReq.FolderId)
+	require.Equal(t, "net-new", restoreReq.NetworkId)
+	require.Equal(t, []string{"sg-new"}, restoreReq.SecurityGroupIds)
+	require.Equal(t, []*redis.HostSpec{
+		{ZoneId: "ru-central1-a", SubnetId: "subnet-new-a"},
+		{ZoneId: "ru-central1-b", SubnetId: "subnet-new-b"},
+	}, restoreReq.HostSpecs)
+	require.Equal(t, "passw0rd", restoreReq.ConfigSpec.GetRedisConfig_6_0().GetPassword())
+
+	// subnets are checked against the declared network, not the one of the source cluster
+	subnetNetworkID := func(subnetID string) (string, error) {
+		if strings.HasPrefix(subnetID, "subnet-new-") {
+			return "net-new", nil
+		}
+		return "net-source", nil
+	}
+	require.NoError(t, checkRedisHostSubnets(restoreReq.NetworkId, []string{"subnet-new-a", "subnet-new-b"}, subnetNetworkID))
+	err = checkRedisHostSubnets(restoreReq.NetworkId, []string{"subnet-new-a", "subnet-source-a"}, subnetNetworkID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Subnet "subnet-source-a" of Redis host belongs to network "net-source", not to the cluster network "net-new"`)
+}
+
+func TestFlattenRedisPlannedOperation(t *testing.T) {
+	require.Empty(t, flattenRedisPlannedOperation(nil))
+
+	op := &redis.MaintenanceOperation{
+		Info:         "Upgrade Redis",
+		DelayedUntil: &timestamp.Timestamp{Seconds: 1625140800},
+	}
+	require.Equal(t, []map[string]interface{}{
+		{"info": "Upgrade Redis", "delayed_until": "2021-07-01T12:00:00Z"},
+	}, flattenRedisPlannedOperation(op))
+}
+
+func TestRedisEnvironmentDiffCustomize(t *testing.T) {
+	production := testRedisClusterRaw(16)
+	production["environment"] = "PRODUCTION"
+
+	_, err := testRedisClusterDiff(t, nil, production)
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiff(t, testRedisClusterRaw(16), production)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Redis Cluster "redis-tf-name" from PRESTABLE to PRODUCTION`)
+
+	production["allow_environment_change"] = true
+	diff, err := testRedisClusterDiff(t, testRedisClusterRaw(16), production)
+	require.NoError(t, err)
+	require.True(t, diff.RequiresNew())
+}
+
+func TestRedisTLSDiffCustomize(t *testing.T) {
+	withTLS := testRedisClusterRaw(16)
+	withTLS["tls_enabled"] = true
+
+	_, err := testRedisClusterDiff(t, nil, withTLS)
+	require.NoError(t, err)
+
+	// advisory only
+	diff, err := testRedisClusterDiff(t, testRedisClusterRaw(16), withTLS)
+	require.NoError(t, err)
+	require.True(t, diff.RequiresNew())
+
+	require.Contains(t, redisTLSChangeWarning("redis-tf-name", false, true),
+		`tls_enabled of Redis Cluster "redis-tf-name" from false to true is not supported by Yandex Cloud for existing clusters`)
+}
+
+func TestRedisDatabasesDiffCustomize(t *testing.T) {
+	withDatabases := func(sharded bool, databases int) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["sharded"] = sharded
+		raw["config"].([]interface{})[0].(map[string]interface{})["databases"] = databases
+		return raw
+	}
+
+	_, err := testRedisClusterDiff(t, nil, withDatabases(true, 16))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Redis Cluster "redis-tf-name" is sharded and supports only a single database`)
+
+	_, err = testRedisClusterDiff(t, nil, withDatabases(true, 1))
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiff(t, nil, withDatabases(false, 16))
+	require.NoError(t, err)
+
+	sharded := testRedisClusterRaw(16)
+	sharded["sharded"] = true
+	_, err = testRedisClusterDiff(t, nil, sharded)
+	require.NoError(t, err)
+
+	// the default reported by the API for an existing sharded cluster is fine
+	_, err = testRedisClusterDiff(t, withDatabases(true, 16), sharded)
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiff(t, withDatabases(true, 1), withDatabases(true, 8))
+	require.Error(t, err)
+
+	// a cluster becoming sharded is checked as well
+	_, err = testRedisClusterDiff(t, withDatabases(false, 16), withDatabases(true, 16))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Redis Cluster "redis-tf-name" is sharded and supports only a single database`)
+
+	_, err = testRedisClusterDiff(t, withDatabases(false, 16), withDatabases(true, 1))
+	require.NoError(t, err)
+
+	// the default reported by the API for the unsharded cluster is not carried over
+	_, err = testRedisClusterDiff(t, withDatabases(false, 16), sharded)
+	require.NoError(t, err)
+}
+
+func TestRedisEvenShardHostsWarnings(t *testing.T) {
+	hosts := []interface{}{
+		map[string]interface{}{"zone": "ru-central1-a", "shard_name": "second"},
+		map[string]interface{}{"zone": "ru-central1-a", "shard_name": "first"},
+		map[string]interface{}{"zone": "ru-central1-b", "shard_name": "first"},
+		map[string]interface{}{"zone": "ru-central1-c", "shard_name": "third"},
+		map[string]interface{}{"zone": "ru-central1-b", "shard_name": "third"},
+		map[string]interface{}{"zone": "ru-central1-a", "shard_name": "third"},
+	}
+
+	warnings := redisEvenShardHostsWarnings("redis-tf-name", hosts)
+	require.Equal(t, []string{
+		`Shard "first" of Redis Cluster "redis-tf-name" has 2 hosts, an odd number of hosts per shard is recommended for quorum on failover`,
+	}, warnings)
+
+	require.Empty(t, redisEvenShardHostsWarnings("redis-tf-name", hosts[:1]))
+
+	// the warning doesn't fail the plan
+	raw := testRedisClusterRaw(16)
+	raw["sharded"] = true
+	raw["host"] = hosts
+	_, err := testRedisClusterDiff(t, nil, raw)
+	require.NoError(t, err)
+}
+
+func TestRedisResourcePresetFamilyWarning(t *testing.T) {
+	presets := []*redis.ResourcePreset{
+		{Id: "b2.nano", Cores: 2, Memory: toBytes(2), ZoneIds: testRedisZones},
+		{Id: "hm1.nano", Cores: 2, Memory: toBytes(8), ZoneIds: testRedisZones},
+		{Id: "hm1.large", Cores: 4, Memory: toBytes(16), ZoneIds: testRedisZones},
+		{Id: "hm3-c2-m8", Cores: 2, Memory: toBytes(8), ZoneIds: testRedisZones},
+	}
+	preset := func(presetID string) *redis.ResourcePreset {
+		return findRedisResourcePreset(presets, presetID)
+	}
+
+	require.Equal(t,
+		`Resource preset of Redis Cluster "redis-tf-name" is changed from "b2.nano" to "hm3-c2-m8" of the other family "hm3" `+
+			"(4 GB instead of 1 GB of memory per core), it may take extended downtime to move the hosts",
+		redisResourcePresetFamilyWarning("redis-tf-name", preset("b2.nano"), preset("hm3-c2-m8")))
+
+	// resize within the family is fine
+	require.Empty(t, redisResourcePresetFamilyWarning("redis-tf-name", preset("hm1.nano"), preset("hm1.large")))
+
+	// other name prefix of the same hardware is fine
+	require.Empty(t, redisResourcePresetFamilyWarning("redis-tf-name", preset("hm1.nano"), preset("hm3-c2-m8")))
+
+	// unknown presets are left to the resource preset check
+	require.Empty(t, redisResourcePresetFamilyWarning("redis-tf-name", preset("b2.nano"), preset("hm1.nan")))
+
+	withPreset := func(presetID string) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["resources"].([]interface{})[0].(map[string]interface{})["resource_preset_id"] = presetID
+		return raw
+	}
+
+	// advisory only
+	config := &Config{redisCache: &redisProviderCache{resourcePresets: presets}}
+	diff, err := testRedisClusterDiffWithMeta(t, withPreset("hm1.nano"), withPreset("b2.nano"), config)
+	require.NoError(t, err)
+	require.Equal(t, "b2.nano", diff.Attributes["resources.0.resource_preset_id"].New)
+	require.False(t, diff.RequiresNew())
+}
+
+func TestRedisHostRedundancyDiffCustomize(t *testing.T) {
+	shardHosts := func(counts ...int) []interface{} {
+		hosts := []interface{}{}
+		for i, count := range counts {
+			for j := 0; j < count; j++ {
+				hosts = append(hosts, map[string]interface{}{"zone": "ru-central1-a", "shard_name": fmt.Sprintf("shard%d", i+1)})
+			}
+		}
+		return hosts
+	}
+
+	warnings := redisSingleHostShardsWarnings("redis-tf-name", shardHosts(2, 3), shardHosts(1, 3), true)
+	require.Equal(t, []string{
+		`Shard "shard1" of Redis Cluster "redis-tf-name" is scaled down from 2 hosts to a single host and has no replicas left`,
+	}, warnings)
+
+	// new single host shards and shards which had no replicas anyway are fine
+	require.Empty(t, redisSingleHostShardsWarnings("redis-tf-name", shardHosts(1, 3), shardHosts(1, 3, 1), true))
+
+	warnings = redisSingleHostShardsWarnings("redis-tf-name", shardHosts(2), []interface{}{
+		map[string]interface{}{"zone": "ru-central1-a", "shard_name": ""},
+	}, false)
+	require.Equal(t, []string{
+		`Redis Cluster "redis-tf-name" is scaled down from 2 hosts to a single host and has no replicas left`,
+	}, warnings)
+
+	oldRaw := testRedisClusterRaw(16)
+	oldRaw["sharded"] = true
+	oldRaw["host"] = shardHosts(3, 2)
+	newRaw := testRedisClusterRaw(16)
+	newRaw["sharded"] = true
+	newRaw["host"] = shardHosts(3, 1)
+
+	// advisory by default
+	_, err := testRedisClusterDiff(t, oldRaw, newRaw)
+	require.NoError(t, err)
+
+	newRaw["require_host_redundancy"] = true
+	_, err = testRedisClusterDiff(t, oldRaw, newRaw)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Shard "shard2" of Redis Cluster "redis-tf-name" is scaled down from 2 hosts to a single host`)
+}
+
+func TestCheckRedisHostSubnets(t *testing.T) {
+	lookups := 0
+	subnetNetworkID := func(subnetID string) (string, error) {
+		lookups++
+		switch subnetID {
+		case "subnet-a", "subnet-b":
+			return "net-777", nil
+		case "subnet-other":
+			return "net-888", nil
+		}
+		return "", status.Error(codes.NotFound, "subnet not found")
+	}
+
+	require.NoError(t, checkRedisHostSubnets("net-777", []string{"subnet-a", "subnet-b", "subnet-a"}, subnetNetworkID))
+	require.Equal(t, 2, lookups)
+
+	// subnets that can't be resolved are not validated
+	require.NoError(t, checkRedisHostSubnets("net-777", []string{"subnet-a", "subnet-missing"}, subnetNetworkID))
+
+	err := checkRedisHostSubnets("net-777", []string{"subnet-a", "subnet-other"}, subnetNetworkID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Subnet "subnet-other" of Redis host belongs to network "net-888", not to the cluster network "net-777"`)
+
+	// offline plan doesn't resolve subnets
+	raw := testRedisClusterRaw(16)
+	raw["host"] = []interface{}{
+		map[string]interface{}{"zone": "ru-central1-a", "subnet_id": "subnet-other"},
+	}
+	_, err = testRedisClusterDiffWithMeta(t, nil, raw, &Config{})
+	require.NoError(t, err)
+}
+
+func TestRedisHostZonesDiffCustomize(t *testing.T) {
+	config := &Config{redisCache: &redisProviderCache{resourcePresets: []*redis.ResourcePreset{
+		{Id: "hm1.nano", ZoneIds: testRedisZones},
+	}}}
+	withZones := func(presetID string, zones ...string) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["resources"].([]interface{})[0].(map[string]interface{})["resource_preset_id"] = presetID
+		hosts := []interface{}{}
+		for _, z := range zones {
+			hosts = append(hosts, map[string]interface{}{"zone": z})
+		}
+		raw["host"] = hosts
+		return raw
+	}
+
+	_, err := testRedisClusterDiffWithMeta(t, nil, withZones("hm1.nano", "ru-central1-a", "ru-central1-c"), config)
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiffWithMeta(t, nil, withZones("hm1.nano", "ru-central1-a", "ru-central1-x"), config)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Zone "ru-central1-x" of Redis host is unknown or resource preset "hm1.nano" is not available in it, `+
+		"the preset is available in zones: ru-central1-a, ru-central1-b, ru-central1-c")
+
+	// offline plan doesn't resolve presets
+	_, err = testRedisClusterDiffWithMeta(t, nil, withZones("hm1.nano", "ru-central1-x"), &Config{})
+	require.NoError(t, err)
+}
+
+func TestRedisResourcePresetDiffCustomize(t *testing.T) {
+	config := &Config{redisCache: &redisProviderCache{resourcePresets: []*redis.ResourcePreset{
+		{Id: "hm1.nano", ZoneIds: testRedisZones}, {Id: "b2.nano", ZoneIds: testRedisZones},
+		{Id: "hm1.large", ZoneIds: testRedisZones}, {Id: "hm1.micro", ZoneIds: testRedisZones},
+	}}}
+
+	_, err := testRedisClusterDiffWithMeta(t, nil, testRedisClusterRaw(16), config)
+	require.NoError(t, err)
+
+	raw := testRedisClusterRaw(16)
+	raw["resources"].([]interface{})[0].(map[string]interface{})["resource_preset_id"] = "hm1.nan"
+	_, err = testRedisClusterDiffWithMeta(t, nil, raw, config)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Unknown Redis resource_preset_id "hm1.nan", the nearest valid presets are: hm1.nano, b2.nano, hm1.large`)
+
+	// unchanged preset is not validated
+	_, err = testRedisClusterDiffWithMeta(t, raw, raw, config)
+	require.NoError(t, err)
+
+	// provider without credentials doesn't validate presets
+	_, err = testRedisClusterDiffWithMeta(t, nil, raw, &Config{})
+	require.NoError(t, err)
+}
+
+func TestNearestStrings(t *testing.T) {
+	candidates := []string{"s2.micro", "hm1.nano", "hm1.micro", "b2.nano"}
+	require.Equal(t, []string{"hm1.micro", "s2.micro"}, nearestStrings("hm1.micr", candidates, 2))
+	require.Len(t, nearestStrings("hm1.micr", candidates, 10), len(candidates))
+	require.Equal(t, 0, levenshteinDistance("hm1.nano", "hm1.nano"))
+	require.Equal(t, 3, levenshteinDistance("kitten", "sitting"))
+}
+
+func TestRedisDiskTypeValidation(t *testing.T) {
+	withResources := func(presetID, diskTypeID string) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		resources := raw["resources"].([]interface{})[0].(map[string]interface{})
+		resources["resource_preset_id"] = presetID
+		resources["disk_type_id"] = diskTypeID
+		return raw
+	}
+
+	for _, dt := range redisDiskTypes {
+		_, err := testRedisClusterDiff(t, nil, withResources("hm1.nano", dt))
+		require.NoError(t, err, dt)
+	}
+
+	diskType := resourceYandexMDBRedisCluster().Schema["resources"].Elem.(*schema.Resource).Schema["disk_type_id"]
+	_, errs := diskType.ValidateFunc("network-sdd", "resources.0.disk_type_id")
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "[network-ssd network-hdd network-ssd-nonreplicated local-ssd]")
+}
+
+type redisHostsPagesLister struct {
+	pages    [][]*redis.Host
+	failPage int
+	calls    int
+}
+
+func (l *redisHostsPagesLister) ListHosts(ctx context.Context, in *redis.ListClusterHostsRequest, opts ...grpc.CallOption) (*redis.ListClusterHostsResponse, error) {
+	page := 0
+	if in.PageToken != "" {
+		page, _ = strconv.Atoi(in.PageToken)
+	}
+	l.calls++
+
+	if page == l.failPage {
+		return nil, status.Error(codes.Unavailable, "service is unavailable")
+	}
+
+	resp := &redis.ListClusterHostsResponse{Hosts: l.pages[page]}
+	if page+1 < len(l.pages) {
+		resp.NextPageToken = strconv.Itoa(page + 1)
+	}
+	return resp, nil
+}
+
+func (l *redisHostsPagesLister) Get(ctx context.Context, in *redis.GetClusterRequest, opts ...grpc.CallOption) (*redis.Cluster, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (l *redisHostsPagesLister) StartFailover(ctx context.Context, in *redis.StartClusterFailoverRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (l *redisHostsPagesLister) Update(ctx context.Context, in *redis.UpdateClusterRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (l *redisHostsPagesLister) Backup(ctx context.Context, in *redis.BackupClusterRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (l *redisHostsPagesLister) RescheduleMaintenance(ctx context.Context, in *redis.RescheduleMaintenanceRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (l *redisHostsPagesLister) ListBackups(ctx context.Context, in *redis.ListClusterBackupsRequest, opts ...grpc.CallOption) (*redis.ListClusterBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func testRedisHostsPages() [][]*redis.Host {
+	return [][]*redis.Host{
+		{
+			{Name: "host-a.mdb.yandexcloud.net", ZoneId: "ru-central1-a"},
+			{Name: "host-b.mdb.yandexcloud.net", ZoneId: "ru-central1-b"},
+		},
+		{
+			{Name: "host-c.mdb.yandexcloud.net", ZoneId: "ru-central1-c"},
+		},
+	}
+}
+
+func TestListRedisClusterHosts(t *testing.T) {
+	lister := &redisHostsPagesLister{pages: testRedisHostsPages(), failPage: -1}
+
+	hosts, err := listRedisClusterHosts(context.Background(), lister, "cid-777", false)
+	require.NoError(t, err)
+	require.Len(t, hosts, 3)
+	require.Equal(t, 2, lister.calls)
+}
+
+func TestListRedisClusterHosts_SecondPageFails(t *testing.T) {
+	lister := &redisHostsPagesLister{pages: testRedisHostsPages(), failPage: 1}
+
+	_, err := listRedisClusterHosts(context.Background(), lister, "cid-777", false)
+	require.Error(t, err)
+
+	lister = &redisHostsPagesLister{pages: testRedisHostsPages(), failPage: 1}
+
+	hosts, err := listRedisClusterHosts(context.Background(), lister, "cid-777", true)
+	require.NoError(t, err)
+	require.Len(t, hosts, 2)
+	require.Equal(t, "host-a.mdb.yandexcloud.net", hosts[0].Name)
+	require.Equal(t, "host-b.mdb.yandexcloud.net", hosts[1].Name)
+}
+
+func TestListRedisClusterHosts_FirstPageFails(t *testing.T) {
+	lister := &redisHostsPagesLister{pages: testRedisHostsPages(), failPage: 0}
+
+	_, err := listRedisClusterHosts(context.Background(), lister, "cid-777", true)
+	require.Error(t, err)
+	require.Equal(t, 1, lister.calls)
+}
+
+func TestSetRedisHostsState_FQDNs(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	raw["host"] = []interface{}{
+		map[string]interface{}{"zone": "ru-central1-c"},
+		map[string]interface{}{"zone": "ru-central1-a"},
+		map[string]interface{}{"zone": "ru-central1-b"},
+	}
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	d.SetId("cid-777")
+
+	lister := &redisHostsPagesLister{pages: testRedisHostsPages(), failPage: -1}
+	require.NoError(t, setRedisHostsState(context.Background(), d, lister))
+
+	hosts := d.Get("host").([]interface{})
+	fqdns := d.Get("fqdns").([]interface{})
+	require.Len(t, fqdns, len(hosts))
+	for i, h := range hosts {
+		require.Equal(t, h.(map[string]interface{})["fqdn"], fqdns[i])
+	}
+	require.Equal(t, "host-c.mdb.yandexcloud.net", fqdns[0])
+}
+
+func TestFlattenRedisHostEndpoints(t *testing.T) {
+	hosts := []*redis.Host{
+		{Name: "second-a", ShardName: "second", ZoneId: "ru-central1-a", Role: redis.Host_REPLICA},
+		{Name: "first-a", ShardName: "first", ZoneId: "ru-central1-a", Role: redis.Host_MASTER},
+		{Name: "first-b", ShardName: "first", ZoneId: "ru-central1-b", Role: redis.Host_REPLICA},
+		{Name: "second-b", ShardName: "second", ZoneId: "ru-central1-b", Role: redis.Host_MASTER},
+		{Name: "first-c", ShardName: "first", ZoneId: "ru-central1-c", Role: redis.Host_REPLICA},
+	}
+	hs, err := flattenRedisHosts(hosts)
+	require.NoError(t, err)
+
+	master, replicas, shardMasters, shardReplicas := flattenRedisHostEndpoints(hs, true)
+	require.Empty(t, master)
+	require.Equal(t, []string{"first-b", "first-c", "second-a"}, replicas)
+	require.Equal(t, map[string]string{"first": "first-a", "second": "second-b"}, shardMasters)
+	require.Len(t, replicas, len(hosts)-len(shardMasters))
+	require.Equal(t, []map[string]interface{}{
+		{"shard_name": "first", "fqdns": []string{"first-b", "first-c"}},
+		{"shard_name": "second", "fqdns": []string{"second-a"}},
+	}, shardReplicas)
+
+	hs, err = flattenRedisHosts(hosts[1:3])
+	require.NoError(t, err)
+	master, replicas, shardMasters, shardReplicas = flattenRedisHostEndpoints(hs, false)
+	require.Equal(t, "first-a", master)
+	require.Equal(t, []string{"first-b"}, replicas)
+	require.Empty(t, shardMasters)
+	require.Empty(t, shardReplicas)
+}
+
+func TestSortRedisHostsByShardAndZone(t *testing.T) {
+	hosts := []*redis.Host{
+		{Name: "host-d", ShardName: "second", ZoneId: "ru-central1-a"},
+		{Name: "host-c", ShardName: "first", ZoneId: "ru-central1-b"},
+		{Name: "host-b", ShardName: "first", ZoneId: "ru-central1-a"},
+		{Name: "host-a", ShardName: "first", ZoneId: "ru-central1-b"},
+	}
+	sortRedisHostsByShardAndZone(hosts)
+
+	var names []string
+	for _, h := range hosts {
+		names = append(names, h.Name)
+	}
+	require.Equal(t, []string{"host-b", "host-a", "host-c", "host-d"}, names)
+}
+
+func TestRedisHostsDiff(t *testing.T) {
+	currHosts := []*redis.Host{
+		{Name: "old-1", ShardName: "old", ZoneId: "ru-central1-b"},
+		{Name: "old-2", ShardName: "old", ZoneId: "ru-central1-a"},
+		{Name: "kept", ShardName: "kept", ZoneId: "ru-central1-a"},
+	}
+	targetHosts := []*redis.HostSpec{
+		{ShardName: "second", ZoneId: "ru-central1-c", SubnetId: "subnet-c"},
+		{ShardName: "first", ZoneId: "ru-central1-b", SubnetId: "subnet-b"},
+		{ShardName: "kept", ZoneId: "ru-central1-a"},
+		{ShardName: "second", ZoneId: "ru-central1-a", SubnetId: "subnet-a2"},
+		{ShardName: "first", ZoneId: "ru-central1-c", SubnetId: "subnet-c"},
+		{ShardName: "second", ZoneId: "ru-central1-a", SubnetId: "subnet-a1"},
+		{ShardName: "first", ZoneId: "ru-central1-a", SubnetId: "subnet-a"},
+	}
+
+	specs := func(hs []*redis.HostSpec) []string {
+		res := []string{}
+		for _, h := range hs {
+			res = append(res, h.ZoneId+"/"+h.SubnetId)
+		}
+		return res
+	}
+
+	// map iteration order is random, the result must not depend on it
+	for i := 0; i < 20; i++ {
+		toDelete, toAdd := redisHostsDiff(currHosts, targetHosts)
+
+		require.Equal(t, map[string][]string{"old": {"old-1", "old-2"}}, toDelete)
+		require.Len(t, toAdd, 2)
+		require.Equal(t, []string{
+			"ru-central1-a/subnet-a",
+			"ru-central1-b/subnet-b",
+			"ru-central1-c/subnet-c",
+		}, specs(toAdd["first"]))
+		require.Equal(t, []string{
+			"ru-central1-a/subnet-a1",
+			"ru-central1-a/subnet-a2",
+			"ru-central1-c/subnet-c",
+		}, specs(toAdd["second"]))
+	}
+}
+
+func TestPlanRedisHostsUpdate_ZoneChange(t *testing.T) {
+	currHosts := []*redis.Host{
+		{Name: "master", ShardName: "shard1", ZoneId: "ru-central1-a"},
+		{Name: "replica", ShardName: "shard1", ZoneId: "ru-central1-b"},
+	}
+	targetHosts := []*redis.HostSpec{
+		{ShardName: "shard1", ZoneId: "ru-central1-a"},
+		{ShardName: "shard1", ZoneId: "ru-central1-c"},
+	}
+
+	steps := planRedisHostsUpdate(currHosts, []*redis.Shard{{Name: "shard1"}}, targetHosts, false, true)
+	require.Len(t, steps, 2)
+	require.Equal(t, redisAddHosts, steps[0].action)
+	require.Equal(t, "ru-central1-c", steps[0].specs[0].ZoneId)
+	require.Equal(t, redisDeleteHosts, steps[1].action)
+	require.Equal(t, []string{"replica"}, steps[1].fqdns)
+
+	// the replica is moved without the shard dropping below two hosts
+	hostCount := len(currHosts)
+	for _, step := range steps {
+		hostCount += len(step.specs) - len(step.fqdns)
+		require.GreaterOrEqual(t, hostCount, 2)
+	}
+	require.Equal(t, 2, hostCount)
+}
+
+func TestPlanRedisHostsUpdate_ShardsRebalanceLast(t *testing.T) {
+	currHosts := []*redis.Host{
+		{Name: "first-a", ShardName: "first", ZoneId: "ru-central1-a"},
+		{Name: "first-b", ShardName: "first", ZoneId: "ru-central1-b"},
+		{Name: "old-a", ShardName: "old", ZoneId: "ru-central1-a"},
+	}
+	currShards := []*redis.Shard{{Name: "first"}, {Name: "old"}}
+	targetHosts := []*redis.HostSpec{
+		{ShardName: "first", ZoneId: "ru-central1-a"},
+		{ShardName: "first", ZoneId: "ru-central1-c"},
+		{ShardName: "new", ZoneId: "ru-central1-a"},
+	}
+
+	actions := func(steps []redisHostsUpdateStep) []redisHostsUpdateAction {
+		res := []redisHostsUpdateAction{}
+		for _, s := range steps {
+			res = append(res, s.action)
+		}
+		return res
+	}
+
+	steps := planRedisHostsUpdate(currHosts, currShards, targetHosts, true, false)
+	require.Equal(t, []redisHostsUpdateAction{redisAddHosts, redisAddShard, redisDeleteHosts, redisDeleteShard, redisRebalance}, actions(steps))
+	require.Equal(t, "first", steps[0].shardName)
+	require.Equal(t, "new", steps[1].shardName)
+	require.Equal(t, []string{"first-b"}, steps[2].fqdns)
+	require.Equal(t, "old", steps[3].shardName)
+
+	steps = planRedisHostsUpdate(currHosts, currShards, targetHosts, true, true)
+	require.Equal(t, []redisHostsUpdateAction{redisAddHosts, redisAddShard, redisDeleteHosts, redisDeleteShard}, actions(steps))
+}
+
+func TestRedisUpdateTimeoutWarning(t *testing.T) {
+	currHosts := []*redis.Host{
+		{Name: "first-a", ShardName: "first", ZoneId: "ru-central1-a"},
+		{Name: "second-a", ShardName: "second", ZoneId: "ru-central1-a"},
+		{Name: "third-a", ShardName: "third", ZoneId: "ru-central1-a"},
+	}
+	currShards := []*redis.Shard{{Name: "first"}, {Name: "second"}, {Name: "third"}}
+
+	// scale out from 3 to 9 shards and add a replica to every existing shard
+	targetHosts := []*redis.HostSpec{}
+	for _, name := range []string{"first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth"} {
+		targetHosts = append(targetHosts, &redis.HostSpec{ShardName: name, ZoneId: "ru-central1-a"})
+		if name == "first" || name == "second" || name == "third" {
+			targetHosts = append(targetHosts, &redis.HostSpec{ShardName: name, ZoneId: "ru-central1-b"})
+		}
+	}
+	steps := planRedisHostsUpdate(currHosts, currShards, targetHosts, true, true)
+	require.Equal(t, 9, countRedisHostOperations(steps))
+
+	warning := redisUpdateTimeoutWarning(steps, 5*time.Minute)
+	require.Contains(t, warning, "update timeout 5m0s is likely too short for 9 host and shard operations")
+	require.Empty(t, redisUpdateTimeoutWarning(steps, 2*time.Hour))
+
+	// shards added without rebalancing are rebalanced by one more operation at the end
+	require.Equal(t, 10, countRedisHostOperations(planRedisHostsUpdate(currHosts, currShards, targetHosts, true, false)))
+
+	// nothing to change
+	targetHosts = []*redis.HostSpec{
+		{ShardName: "first", ZoneId: "ru-central1-a"},
+		{ShardName: "second", ZoneId: "ru-central1-a"},
+		{ShardName: "third", ZoneId: "ru-central1-a"},
+	}
+	require.Empty(t, redisUpdateTimeoutWarning(planRedisHostsUpdate(currHosts, currShards, targetHosts, true, true), time.Minute))
+}
+
+func TestRedisHostsDiff_SubnetChange(t *testing.T) {
+	currHosts := []*redis.Host{
+		{Name: "host-a1", ZoneId: "ru-central1-a", SubnetId: "subnet-a1"},
+		{Name: "host-a2", ZoneId: "ru-central1-a", SubnetId: "subnet-a2"},
+		{Name: "host-b", ZoneId: "ru-central1-b", SubnetId: "subnet-b"},
+	}
+
+	// the host without explicit subnet keeps host-a1, host-a2 moves to a new subnet in the same zone
+	targetHosts := []*redis.HostSpec{
+		{ZoneId: "ru-central1-a"},
+		{ZoneId: "ru-central1-a", SubnetId: "subnet-a3"},
+		{ZoneId: "ru-central1-b", SubnetId: "subnet-b"},
+	}
+	toDelete, toAdd := redisHostsDiff(currHosts, targetHosts)
+	require.Equal(t, map[string][]string{"": {"host-a2"}}, toDelete)
+	require.Equal(t, map[string][]*redis.HostSpec{"": {{ZoneId: "ru-central1-a", SubnetId: "subnet-a3"}}}, toAdd)
+
+	// hosts without explicit subnets match any subnet
+	toDelete, toAdd = redisHostsDiff(currHosts, []*redis.HostSpec{
+		{ZoneId: "ru-central1-a"},
+		{ZoneId: "ru-central1-a", SubnetId: "subnet-a1"},
+		{ZoneId: "ru-central1-b"},
+	})
+	require.Empty(t, toDelete)
+	require.Empty(t, toAdd)
+}
+
+func testRedisClusterConfig() *redis.ClusterConfig {
+	return &redis.ClusterConfig{
+		Version: "6.0",
+		RedisConfig: &redis.ClusterConfig_RedisConfig_6_0{
+			RedisConfig_6_0: &config.RedisConfigSet6_0{
+				EffectiveConfig: &config.RedisConfig6_0{
+					MaxmemoryPolicy: config.RedisConfig6_0_VOLATILE_LRU,
+					Timeout:         &wrappers.Int64Value{Value: 100},
+					Databases:       &wrappers.Int64Value{Value: 16},
+				},
+			},
+		},
+		Resources: &redis.Resources{
+			ResourcePresetId: "hm1.nano",
+			DiskSize:         16 * (1 << 30),
+			DiskTypeId:       "network-ssd",
+		},
+	}
+}
+
+func TestRedisConfigFingerprint(t *testing.T) {
+	fp := redisConfigFingerprint(testRedisClusterConfig())
+	require.NotEmpty(t, fp)
+	require.Equal(t, fp, redisConfigFingerprint(testRedisClusterConfig()), "fingerprint should be stable")
+
+	// password is not returned by API and is not a part of the fingerprint
+	cc := testRedisClusterConfig()
+	cc.GetRedisConfig_6_0().EffectiveConfig.Password = "passw0rd"
+	require.Equal(t, fp, redisConfigFingerprint(cc))
+
+	changes := map[string]func(cc *redis.ClusterConfig){
+		"timeout": func(cc *redis.ClusterConfig) {
+			cc.GetRedisConfig_6_0().EffectiveConfig.Timeout = &wrappers.Int64Value{Value: 200}
+		},
+		"maxmemory_policy": func(cc *redis.ClusterConfig) {
+			cc.GetRedisConfig_6_0().EffectiveConfig.MaxmemoryPolicy = config.RedisConfig6_0_ALLKEYS_LRU
+		},
+		"notify_keyspace_events": func(cc *redis.ClusterConfig) {
+			cc.GetRedisConfig_6_0().EffectiveConfig.NotifyKeyspaceEvents = "Elg"
+		},
+		"version": func(cc *redis.ClusterConfig) {
+			cc.Version = "5.0"
+		},
+		"resource_preset_id": func(cc *redis.ClusterConfig) {
+			cc.Resources.ResourcePresetId = "hm1.micro"
+		},
+		"disk_size": func(cc *redis.ClusterConfig) {
+			cc.Resources.DiskSize = 24 * (1 << 30)
+		},
+	}
+	for name, change := range changes {
+		cc := testRedisClusterConfig()
+		change(cc)
+		require.NotEqual(t, fp, redisConfigFingerprint(cc), "fingerprint should change with %s", name)
+	}
+
+	// empty config doesn't panic
+	require.NotEmpty(t, redisConfigFingerprint(&redis.ClusterConfig{}))
+}
+
+func TestRedisEffectiveConfigJSON(t *testing.T) {
+	cc := testRedisClusterConfig()
+	cc.GetRedisConfig_6_0().EffectiveConfig.Password = "passw0rd"
+
+	s, err := redisEffectiveConfigJSON(cc)
+	require.NoError(t, err)
+	require.NotContains(t, s, "passw0rd")
+	require.NotContains(t, s, "password")
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &out))
+	require.Equal(t, "6.0", out["version"])
+	require.Equal(t, "VOLATILE_LRU", out["maxmemory_policy"])
+	require.Equal(t, float64(100), out["timeout"])
+	require.Equal(t, float64(16), out["databases"])
+	require.Equal(t, map[string]interface{}{
+		"resource_preset_id": "hm1.nano",
+		"disk_size":          float64(16),
+		"disk_type_id":       "network-ssd",
+	}, out["resources"])
+}
+
+func TestFetchRedisTLSCACert(t *testing.T) {
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("test certificate")}))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/CA.pem":
+			fmt.Fprint(w, caPEM)
+		case "/garbage.pem":
+			fmt.Fprint(w, "<html>not a certificate</html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cert, err := fetchRedisTLSCACert(context.Background(), srv.URL+"/CA.pem")
+	require.NoError(t, err)
+	require.Equal(t, caPEM, cert)
+
+	_, err = fetchRedisTLSCACert(context.Background(), srv.URL+"/garbage.pem")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no PEM encoded certificate found")
+
+	_, err = fetchRedisTLSCACert(context.Background(), srv.URL+"/missing.pem")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected status 404")
+}
+
+func TestGetRedisTLSCACertCached(t *testing.T) {
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("test certificate")}))
+
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if r.URL.Path == "/CA.pem" {
+			fmt.Fprint(w, caPEM)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	defer func(url string) { redisTLSCACertURL = url }(redisTLSCACertURL)
+
+	// failed fetch is an error and is not cached
+	redisTLSCACertURL = srv.URL + "/missing.pem"
+	config := &Config{redisCache: &redisProviderCache{}}
+	_, err := getRedisTLSCACert(context.Background(), config)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected status 404")
+	require.Equal(t, 1, fetches)
+
+	// the certificate is fetched once per provider
+	redisTLSCACertURL = srv.URL + "/CA.pem"
+	for i := 0; i < 2; i++ {
+		cert, err := getRedisTLSCACert(context.Background(), config)
+		require.NoError(t, err)
+		require.Equal(t, caPEM, cert)
+	}
+	require.Equal(t, 2, fetches)
+}
+
+type redisOperationsGetter struct {
+	pollsUntilDone int
+	polls          int
+	cancellable    bool
+	cancels        int
+}
+
+func (c *redisOperationsGetter) Get(ctx context.Context, in *ycoperation.GetOperationRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	c.polls++
+	op := &ycoperation.Operation{Id: in.OperationId, Description: "Add shard to Redis cluster"}
+	if c.polls >= c.pollsUntilDone {
+		op.Done = true
+		resp, err := ptypes.MarshalAny(&redis.Cluster{Id: "cid-777"})
+		if err != nil {
+			return nil, err
+		}
+		op.Result = &ycoperation.Operation_Response{Response: resp}
+	}
+	return op, nil
+}
+
+func (c *redisOperationsGetter) Cancel(ctx context.Context, in *ycoperation.CancelOperationRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	c.cancels++
+	if !c.cancellable {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	return &ycoperation.Operation{
+		Id:     in.OperationId,
+		Done:   true,
+		Result: &ycoperation.Operation_Error{Error: status.New(codes.Canceled, "operation cancelled").Proto()},
+	}, nil
+}
+
+func TestWaitOperationWithProgress(t *testing.T) {
+	client := &redisOperationsGetter{pollsUntilDone: 3}
+	op := operation.New(client, &ycoperation.Operation{Id: "op-777"})
+
+	err := waitOperationWithProgress(context.Background(), op, 10*time.Millisecond, time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, op.Done())
+	require.Equal(t, 3, client.polls)
+}
+
+func TestWaitOperationWithProgress_ContextDone(t *testing.T) {
+	client := &redisOperationsGetter{pollsUntilDone: math.MaxInt32}
+	op := operation.New(client, &ycoperation.Operation{Id: "op-777"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := waitOperationWithProgress(ctx, op, 10*time.Millisecond, time.Millisecond)
+	require.Error(t, err)
+	require.False(t, op.Done())
+}
+
+func TestWaitOperationWithProgress_LongPollInterval(t *testing.T) {
+	client := &redisOperationsGetter{pollsUntilDone: math.MaxInt32}
+	op := operation.New(client, &ycoperation.Operation{Id: "op-777"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// progress is logged more often than the operation is polled, the polls are not restarted by it
+	err := waitOperationWithProgress(ctx, op, time.Millisecond, 40*time.Millisecond)
+	require.Error(t, err)
+	require.LessOrEqual(t, client.polls, 3)
+}
+
+type redisOperationsErrorGetter struct {
+	err error
+}
+
+func (c *redisOperationsErrorGetter) Get(ctx context.Context, in *ycoperation.GetOperationRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	return nil, c.err
+}
+
+func (c *redisOperationsErrorGetter) Cancel(ctx context.Context, in *ycoperation.CancelOperationRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func TestWaitRedisOperationCancelsOnTimeout(t *testing.T) {
+	client := &redisOperationsGetter{pollsUntilDone: math.MaxInt32, cancellable: true}
+	op := operation.New(client, &ycoperation.Operation{Id: "op-777", Description: "Add shard to Redis cluster"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitRedisOperation(ctx, op, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "operation op-777 (Add shard to Redis cluster) was cancelled")
+	require.Equal(t, 1, client.cancels)
+
+	// the operation can't be cancelled
+	client = &redisOperationsGetter{pollsUntilDone: math.MaxInt32}
+	op = operation.New(client, &ycoperation.Operation{Id: "op-777", Description: "Add shard to Redis cluster"})
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = waitRedisOperation(ctx, op, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "couldn't be cancelled and may still be running")
+	require.Equal(t, 1, client.cancels)
+
+	// finished operations are not cancelled
+	client = &redisOperationsGetter{pollsUntilDone: 2, cancellable: true}
+	op = operation.New(client, &ycoperation.Operation{Id: "op-777"})
+	require.NoError(t, waitRedisOperation(context.Background(), op, time.Millisecond))
+	require.Equal(t, 0, client.cancels)
+}
+
+func TestWaitRedisClusterDeletion(t *testing.T) {
+	// the cluster is gone while the operation is polled
+	op := operation.New(&redisOperationsErrorGetter{err: status.Error(codes.NotFound, "cluster not found")}, &ycoperation.Operation{Id: "op-777"})
+	require.NoError(t, waitRedisClusterDeletion(context.Background(), op, time.Millisecond, "cid-777"))
+
+	// the operation itself failed because the cluster is gone
+	op = operation.New(&redisOperationsErrorGetter{}, &ycoperation.Operation{
+		Id:     "op-777",
+		Done:   true,
+		Result: &ycoperation.Operation_Error{Error: status.New(codes.NotFound, "cluster not found").Proto()},
+	})
+	require.NoError(t, waitRedisClusterDeletion(context.Background(), op, time.Millisecond, "cid-777"))
+
+	op = operation.New(&redisOperationsErrorGetter{err: status.Error(codes.PermissionDenied, "permission denied")}, &ycoperation.Operation{Id: "op-777"})
+	err := waitRedisClusterDeletion(context.Background(), op, time.Millisecond, "cid-777")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission denied")
+
+	op = operation.New(&redisOperationsGetter{pollsUntilDone: 2}, &ycoperation.Operation{Id: "op-777"})
+	require.NoError(t, waitRedisClusterDeletion(context.Background(), op, time.Millisecond, "cid-777"))
+}
+
+type redisClusterUpdater struct {
+	redisHostsPagesLister
+	conflicts int
+	err       error
+	opErr     *status.Status
+	labels    map[string]string
+	updates   []*redis.UpdateClusterRequest
+}
+
+func (u *redisClusterUpdater) Get(ctx context.Context, in *redis.GetClusterRequest, opts ...grpc.CallOption) (*redis.Cluster, error) {
+	return &redis.Cluster{Id: in.ClusterId, Labels: u.labels}, nil
+}
+
+func (u *redisClusterUpdater) Update(ctx context.Context, in *redis.UpdateClusterRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	u.updates = append(u.updates, proto.Clone(in).(*redis.UpdateClusterRequest))
+	if len(u.updates) <= u.conflicts {
+		return nil, status.Error(codes.Aborted, "cluster has another operation in progress")
+	}
+	if u.err != nil {
+		return nil, u.err
+	}
+	op := &ycoperation.Operation{Id: "op-update", Description: "Update Redis cluster", Done: true}
+	if u.opErr != nil {
+		op.Result = &ycoperation.Operation_Error{Error: u.opErr.Proto()}
+	}
+	return op, nil
+}
+
+func TestUpdateRedisClusterRetryingConflicts(t *testing.T) {
+	defer func(delay time.Duration) { redisUpdateConflictRetryDelay = delay }(redisUpdateConflictRetryDelay)
+	redisUpdateConflictRetryDelay = time.Millisecond
+
+	testRequest := func() *redis.UpdateClusterRequest {
+		return &redis.UpdateClusterRequest{
+			ClusterId:  "cid-777",
+			Labels:     map[string]string{"key": "value"},
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"labels"}},
+		}
+	}
+
+	updater := &redisClusterUpdater{conflicts: 1, labels: map[string]string{"yandex.cloud/system": "yes", "stale": "label"}}
+	err := updateRedisClusterRetryingConflicts(context.Background(), updater, &redisOperationsGetter{}, testRequest(), nil, time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, updater.updates, 2)
+	require.Equal(t, map[string]string{"key": "value"}, updater.updates[0].Labels)
+	require.Equal(t, map[string]string{"key": "value", "yandex.cloud/system": "yes"}, updater.updates[1].Labels)
+
+	// conflicts are retried a bounded number of times
+	updater = &redisClusterUpdater{conflicts: math.MaxInt32}
+	err = updateRedisClusterRetryingConflicts(context.Background(), updater, &redisOperationsGetter{}, testRequest(), nil, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "another operation in progress")
+	require.Len(t, updater.updates, redisUpdateConflictRetries+1)
+
+	// other errors are not retried
+	updater = &redisClusterUpdater{err: status.Error(codes.InvalidArgument, "invalid labels")}
+	err = updateRedisClusterRetryingConflicts(context.Background(), updater, &redisOperationsGetter{}, testRequest(), nil, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Error updating Redis Cluster "cid-777"`)
+	require.Len(t, updater.updates, 1)
+
+	// the update operation failing with a conflict once started is not retried
+	updater = &redisClusterUpdater{opErr: status.New(codes.FailedPrecondition, "cluster is stopped")}
+	err = updateRedisClusterRetryingConflicts(context.Background(), updater, &redisOperationsGetter{}, testRequest(), nil, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cluster is stopped")
+	require.Len(t, updater.updates, 1)
+}
+
+func TestRedisErrorWithRequestID(t *testing.T) {
+	st, err := status.New(codes.Internal, "failed to update config").WithDetails(&errdetails.RequestInfo{RequestId: "req-777"})
+	require.NoError(t, err)
+
+	// the operation failed
+	updater := &redisClusterUpdater{opErr: st}
+	err = updateRedisClusterRetryingConflicts(context.Background(), updater, &redisOperationsGetter{}, &redis.UpdateClusterRequest{
+		ClusterId:  "cid-777",
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"description"}},
+	}, nil, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to update config (request ID: req-777)")
+
+	// the request ID is already in the message
+	err = status.Error(codes.Internal, "server-request-id = req-777 failed")
+	st, _ = status.New(codes.Internal, err.Error()).WithDetails(&errdetails.RequestInfo{RequestId: "req-777"})
+	require.Equal(t, st.Err().Error(), redisErrorWithRequestID(st.Err()).Error())
+
+	// no request ID at all
+	err = status.Error(codes.Internal, "failed")
+	require.Equal(t, err, redisErrorWithRequestID(err))
+}
+
+func TestFilterMDBSystemLabels(t *testing.T) {
+	labels := map[string]string{
+		"env":                     "prod",
+		"yandex.cloud/created-by": "console",
+		"yandex.cloud/declared":   "yes",
+	}
+	declared := map[string]interface{}{
+		"yandex.cloud/declared": "yes",
+	}
+
+	require.Equal(t, map[string]string{
+		"env":                   "prod",
+		"yandex.cloud/declared": "yes",
+	}, filterMDBSystemLabels(labels, declared))
+
+	require.Equal(t, map[string]string{"env": "prod"}, filterMDBSystemLabels(labels, nil))
+}
+
+func TestRedisClusterLabelsDiffIgnoresSystemLabels(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	raw["labels"] = map[string]interface{}{"env": "prod"}
+
+	// state as it is read for the cluster with both user and system labels
+	stateLabels := func(apiLabels map[string]string) map[string]interface{} {
+		state := testRedisClusterRaw(16)
+		labels := map[string]interface{}{}
+		for k, v := range filterMDBSystemLabels(apiLabels, raw["labels"].(map[string]interface{})) {
+			labels[k] = v
+		}
+		state["labels"] = labels
+		return state
+	}
+
+	diff, err := testRedisClusterDiff(t, stateLabels(map[string]string{
+		"env":                     "prod",
+		"yandex.cloud/created-by": "console",
+	}), raw)
+	require.NoError(t, err)
+	for k := range diff.Attributes {
+		require.NotContains(t, k, "labels")
+	}
+
+	// user labels still diff normally
+	diff, err = testRedisClusterDiff(t, stateLabels(map[string]string{
+		"env":                     "test",
+		"yandex.cloud/created-by": "console",
+	}), raw)
+	require.NoError(t, err)
+	require.Contains(t, diff.Attributes, "labels.env")
+	require.Equal(t, "prod", diff.Attributes["labels.env"].New)
+}
+
+func TestExpandRedisConfigTimeout(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	spec, _, err := expandRedisConfig(d)
+	require.NoError(t, err)
+	require.Nil(t, (*spec).(*redis.ConfigSpec_RedisConfig_6_0).RedisConfig_6_0.Timeout, "API default should stand")
+
+	// explicit 0 disables the timeout and must be sent
+	raw["config"].([]interface{})[0].(map[string]interface{})["timeout"] = 0
+	d = schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	spec, _, err = expandRedisConfig(d)
+	require.NoError(t, err)
+	require.NotNil(t, (*spec).(*redis.ConfigSpec_RedisConfig_6_0).RedisConfig_6_0.Timeout)
+	require.Equal(t, int64(0), (*spec).(*redis.ConfigSpec_RedisConfig_6_0).RedisConfig_6_0.Timeout.GetValue())
+
+	cc := testRedisClusterConfig()
+	cc.GetRedisConfig_6_0().EffectiveConfig.Timeout = &wrappers.Int64Value{Value: 0}
+	require.Equal(t, int64(0), extractRedisConfig(cc).timeout)
+}
+
+func TestRedisHostOperationContext(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	parentDeadline, _ := parent.Deadline()
+
+	raw := testRedisClusterRaw(16)
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	ctx, opCancel := redisHostOperationContext(parent, d)
+	deadline, ok := ctx.Deadline()
+	opCancel()
+	require.True(t, ok)
+	require.Equal(t, parentDeadline, deadline, "update timeout is used without host_operation_timeout")
+
+	raw["host_operation_timeout"] = "10m"
+	d = schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	ctx, opCancel = redisHostOperationContext(parent, d)
+	deadline, _ = ctx.Deadline()
+	opCancel()
+	require.True(t, deadline.Before(parentDeadline))
+	require.WithinDuration(t, time.Now().Add(10*time.Minute), deadline, time.Minute)
+
+	// update timeout stays the upper bound
+	raw["host_operation_timeout"] = "2h"
+	d = schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+	ctx, opCancel = redisHostOperationContext(parent, d)
+	deadline, _ = ctx.Deadline()
+	opCancel()
+	require.Equal(t, parentDeadline, deadline)
+}
+
+func TestSetRedisHostsState_PartialFailure(t *testing.T) {
+	res := resourceYandexMDBRedisCluster()
+
+	state := schema.TestResourceDataRaw(t, res.Schema, testRedisClusterRaw(16))
+	state.SetId("cid-777")
+
+	// scale out from one to three hosts, failing after the second one is added
+	newRaw := testRedisClusterRaw(16)
+	newRaw["host"] = []interface{}{
+		map[string]interface{}{"zone": "ru-central1-a"},
+		map[string]interface{}{"zone": "ru-central1-b"},
+		map[string]interface{}{"zone": "ru-central1-c"},
+	}
+	diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(newRaw), nil)
+	require.NoError(t, err)
+	d, err := schema.InternalMap(res.Schema).Data(state.State(), diff)
+	require.NoError(t, err)
+	d.Partial(true)
+
+	lister := &redisHostsPagesLister{
+		pages: [][]*redis.Host{{
+			{Name: "host-b.mdb.yandexcloud.net", ZoneId: "ru-central1-b"},
+			{Name: "host-a.mdb.yandexcloud.net", ZoneId: "ru-central1-a"},
+		}},
+		failPage: -1,
+	}
+	require.NoError(t, setRedisHostsState(context.Background(), d, lister))
+
+	attrs := d.State().Attributes
+	require.Equal(t, "2", attrs["host.#"])
+	require.Equal(t, "host-a.mdb.yandexcloud.net", attrs["host.0.fqdn"])
+	require.Equal(t, "ru-central1-a", attrs["host.0.zone"])
+	require.Equal(t, "host-b.mdb.yandexcloud.net", attrs["host.1.fqdn"])
+	require.Equal(t, "ru-central1-b", attrs["host.1.zone"])
+
+	// hosts that can't be listed are reported and the state is left as is
+	err = setRedisHostsState(context.Background(), d, &redisHostsPagesLister{failPage: 0})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "service is unavailable")
+	require.Equal(t, attrs, d.State().Attributes)
+}
+
+type redisClusterHealthGetter struct {
+	redisHostsPagesLister
+	health redis.Cluster_Health
+}
+
+func (g *redisClusterHealthGetter) Get(ctx context.Context, in *redis.GetClusterRequest, opts ...grpc.CallOption) (*redis.Cluster, error) {
+	return &redis.Cluster{Id: in.ClusterId, Health: g.health}, nil
+}
+
+func TestCheckRedisClusterHealth(t *testing.T) {
+	require.NoError(t, checkRedisClusterHealth(context.Background(), &redisClusterHealthGetter{health: redis.Cluster_ALIVE}, "cid-777"))
+	require.NoError(t, checkRedisClusterHealth(context.Background(), &redisClusterHealthGetter{health: redis.Cluster_HEALTH_UNKNOWN}, "cid-777"))
+
+	err := checkRedisClusterHealth(context.Background(), &redisClusterHealthGetter{health: redis.Cluster_DEAD}, "cid-777")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "health is DEAD")
+
+	err = checkRedisClusterHealth(context.Background(), &redisClusterHealthGetter{health: redis.Cluster_DEGRADED}, "cid-777")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "health is DEGRADED")
+
+	err = checkRedisClusterHealth(context.Background(), &redisHostsPagesLister{}, "cid-777")
+	require.Error(t, err)
+}
+
+type redisClusterHealthSequenceGetter struct {
+	redisHostsPagesLister
+	healths []redis.Cluster_Health
+	calls   int
+}
+
+func (g *redisClusterHealthSequenceGetter) Get(ctx context.Context, in *redis.GetClusterRequest, opts ...grpc.CallOption) (*redis.Cluster, error) {
+	health := g.healths[len(g.healths)-1]
+	if g.calls < len(g.healths) {
+		health = g.healths[g.calls]
+	}
+	g.calls++
+	return &redis.Cluster{Id: in.ClusterId, Health: health}, nil
+}
+
+func TestWaitRedisClusterAlive(t *testing.T) {
+	client := &redisClusterHealthSequenceGetter{
+		healths: []redis.Cluster_Health{redis.Cluster_DEGRADED, redis.Cluster_DEGRADED, redis.Cluster_ALIVE},
+	}
+	require.NoError(t, waitRedisClusterAlive(context.Background(), client, "cid-777", time.Millisecond))
+	require.Equal(t, 3, client.calls)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client = &redisClusterHealthSequenceGetter{healths: []redis.Cluster_Health{redis.Cluster_DEGRADED}}
+	err := waitRedisClusterAlive(ctx, client, "cid-777", time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "health is DEGRADED")
+
+	// a dead cluster is reported at once, without waiting for the timeout
+	client = &redisClusterHealthSequenceGetter{healths: []redis.Cluster_Health{redis.Cluster_DEGRADED, redis.Cluster_DEAD, redis.Cluster_ALIVE}}
+	err = waitRedisClusterAlive(context.Background(), client, "cid-777", time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "health is DEAD")
+	require.Equal(t, 2, client.calls)
+
+	err = waitRedisClusterAlive(context.Background(), &redisHostsPagesLister{}, "cid-777", time.Millisecond)
+	require.Error(t, err)
+}
+
+func TestRedisNotifyKeyspaceEventsValidation(t *testing.T) {
+	for _, v := range []string{"", "KEA", "Elg$shzxetmnd"} {
+		_, errs := validateRedisNotifyKeyspaceEvents(v, "config.0.notify_keyspace_events")
+		require.Empty(t, errs, "%q should be valid", v)
+	}
+
+	raw := testRedisClusterRaw(16)
+	raw["config"].([]interface{})[0].(map[string]interface{})["notify_keyspace_events"] = "KEX"
+	ws, errs := resourceYandexMDBRedisCluster().Validate(terraform.NewResourceConfigRaw(raw))
+	require.Empty(t, ws)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "'X'")
+}
+
+func TestRedisSlowlogValidation(t *testing.T) {
+	validate := func(key string, v int) []error {
+		raw := testRedisClusterRaw(16)
+		raw["config"].([]interface{})[0].(map[string]interface{})[key] = v
+		_, errs := resourceYandexMDBRedisCluster().Validate(terraform.NewResourceConfigRaw(raw))
+		return errs
+	}
+
+	require.Empty(t, validate("slowlog_max_len", 0))
+	require.Empty(t, validate("slowlog_max_len", 1000))
+	errs := validate("slowlog_max_len", -1)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "slowlog_max_len")
+
+	// -1 disables the slow log
+	require.Empty(t, validate("slowlog_log_slower_than", -1))
+	require.Empty(t, validate("slowlog_log_slower_than", 10000))
+	errs = validate("slowlog_log_slower_than", -2)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "slowlog_log_slower_than")
+}
+
+func TestRedisPasswordValidation(t *testing.T) {
+	validate := func(password string) []error {
+		raw := testRedisClusterRaw(16)
+		raw["config"].([]interface{})[0].(map[string]interface{})["password"] = password
+		_, errs := resourceYandexMDBRedisCluster().Validate(terraform.NewResourceConfigRaw(raw))
+		return errs
+	}
+
+	require.Empty(t, validate("passw0rd"))
+	require.Empty(t, validate(strings.Repeat("p", 128)))
+	// no password at all
+	require.Empty(t, validate(""))
+
+	errs := validate("short")
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "must be from 8 to 128 characters long, got 5 characters")
+	require.NotContains(t, errs[0].Error(), "short")
+
+	errs = validate("pass w0rd")
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "must not contain whitespace")
+
+	require.NotEmpty(t, validate(strings.Repeat("p", 129)))
+}
+
+func TestRedisMaintenanceWindowHourZero(t *testing.T) {
+	hour := resourceYandexMDBRedisCluster().Schema["maintenance_window"].Elem.(*schema.Resource).Schema["hour"]
+	for _, v := range []int{0, 23} {
+		_, errs := hour.ValidateFunc(v, "maintenance_window.0.hour")
+		require.Empty(t, errs, "hour = %d should be valid", v)
+	}
+	_, errs := hour.ValidateFunc(24, "maintenance_window.0.hour")
+	require.NotEmpty(t, errs)
+
+	raw := testRedisClusterRaw(16)
+	raw["maintenance_window"] = []interface{}{
+		map[string]interface{}{
+			"type": "WEEKLY",
+			"day":  "MON",
+			"hour": 0,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, raw)
+
+	mw, err := expandRedisMaintenanceWindow(d)
+	require.NoError(t, err)
+	require.Equal(t, redis.WeeklyMaintenanceWindow_MON, mw.GetWeeklyMaintenanceWindow().GetDay())
+	require.Equal(t, int64(0), mw.GetWeeklyMaintenanceWindow().GetHour())
+
+	require.Equal(t, []map[string]interface{}{{"type": "WEEKLY", "day": "MON", "hour": int64(0)}}, flattenRedisMaintenanceWindow(mw))
+}
+
+func TestRedisMaintenanceWindowWeeklyToAnytime(t *testing.T) {
+	withWindow := func(mw map[string]interface{}) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["maintenance_window"] = []interface{}{mw}
+		return raw
+	}
+	weekly := withWindow(map[string]interface{}{"type": "WEEKLY", "day": "FRI", "hour": 20})
+	anytime := withWindow(map[string]interface{}{"type": "ANYTIME"})
+
+	d := testRedisClusterUpdateData(t, weekly, anytime)
+	require.True(t, redisClusterParamsChanged(d))
+
+	req, _, err := prepareUpdateRedisClusterParamsRequest(d, &Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"maintenance_window"}, req.UpdateMask.Paths)
+	require.NotNil(t, req.MaintenanceWindow.GetAnytime())
+	require.Nil(t, req.MaintenanceWindow.GetWeeklyMaintenanceWindow())
+
+	// the window read back after the update gives a clean plan
+	res := resourceYandexMDBRedisCluster()
+	state := schema.TestResourceDataRaw(t, res.Schema, testRedisClusterRaw(16))
+	state.SetId("cid-777")
+	require.NoError(t, state.Set("maintenance_window", flattenRedisMaintenanceWindow(req.MaintenanceWindow)))
+	diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(anytime), nil)
+	require.NoError(t, err)
+	if diff != nil {
+		for k := range diff.Attributes {
+			require.NotContains(t, k, "maintenance_window")
+		}
+	}
+
+	// day and hour set along with ANYTIME are still a mistake
+	d = testRedisClusterUpdateData(t, weekly, withWindow(map[string]interface{}{"type": "ANYTIME", "day": "MON"}))
+	_, err = expandRedisMaintenanceWindow(d)
+	require.Error(t, err)
+}
+
+type redisFailoverStarter struct {
+	redisHostsPagesLister
+	failovers []string
+}
+
+func (f *redisFailoverStarter) StartFailover(ctx context.Context, in *redis.StartClusterFailoverRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	f.failovers = append(f.failovers, in.ClusterId)
+	return &ycoperation.Operation{Id: "op-failover", Description: "Start failover of Redis cluster"}, nil
+}
+
+func TestTriggerRedisFailover(t *testing.T) {
+	res := resourceYandexMDBRedisCluster()
+
+	oldRaw := testRedisClusterRaw(16)
+	oldRaw["failover_trigger"] = "2021-07-01"
+	state := schema.TestResourceDataRaw(t, res.Schema, oldRaw)
+	state.SetId("cid-777")
+
+	testDataWithTrigger := func(trigger string) *schema.ResourceData {
+		newRaw := testRedisClusterRaw(16)
+		newRaw["failover_trigger"] = trigger
+		diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(newRaw), nil)
+		require.NoError(t, err)
+		d, err := schema.InternalMap(res.Schema).Data(state.State(), diff)
+		require.NoError(t, err)
+		return d
+	}
+
+	starter := &redisFailoverStarter{}
+	err := triggerRedisFailover(context.Background(), testDataWithTrigger("2021-07-02"), starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cid-777"}, starter.failovers)
+
+	// unchanged or removed trigger doesn't start failover
+	starter = &redisFailoverStarter{}
+	require.NoError(t, triggerRedisFailover(context.Background(), testDataWithTrigger("2021-07-01"), starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.NoError(t, triggerRedisFailover(context.Background(), testDataWithTrigger(""), starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.Empty(t, starter.failovers)
+}
+
+type redisBackupStarter struct {
+	redisHostsPagesLister
+	backups []*redis.Backup
+	// backups started along with the requested one, e.g. scheduled ones
+	concurrent []*redis.Backup
+}
+
+func (f *redisBackupStarter) Backup(ctx context.Context, in *redis.BackupClusterRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	startedAt := &timestamp.Timestamp{Seconds: 1625097600}
+	f.backups = append(f.backups, &redis.Backup{
+		Id:              fmt.Sprintf("backup-%d", len(f.backups)+1),
+		SourceClusterId: in.ClusterId,
+		StartedAt:       startedAt,
+		CreatedAt:       &timestamp.Timestamp{Seconds: startedAt.Seconds + 60},
+	})
+	f.backups = append(f.backups, f.concurrent...)
+	return &ycoperation.Operation{Id: "op-backup", Description: "Backup Redis cluster", CreatedAt: startedAt}, nil
+}
+
+func (f *redisBackupStarter) ListBackups(ctx context.Context, in *redis.ListClusterBackupsRequest, opts ...grpc.CallOption) (*redis.ListClusterBackupsResponse, error) {
+	// the newest backup goes first to make sure that the order of the list doesn't matter
+	resp := &redis.ListClusterBackupsResponse{}
+	for i := len(f.backups) - 1; i >= 0; i-- {
+		resp.Backups = append(resp.Backups, f.backups[i])
+	}
+	return resp, nil
+}
+
+func TestTriggerRedisBackup(t *testing.T) {
+	res := resourceYandexMDBRedisCluster()
+
+	oldRaw := testRedisClusterRaw(16)
+	oldRaw["backup_trigger"] = "2021-07-01"
+	state := schema.TestResourceDataRaw(t, res.Schema, oldRaw)
+	state.SetId("cid-777")
+
+	testDataWithTrigger := func(trigger string) *schema.ResourceData {
+		newRaw := testRedisClusterRaw(16)
+		newRaw["backup_trigger"] = trigger
+		diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(newRaw), nil)
+		require.NoError(t, err)
+		d, err := schema.InternalMap(res.Schema).Data(state.State(), diff)
+		require.NoError(t, err)
+		return d
+	}
+
+	starter := &redisBackupStarter{backups: []*redis.Backup{
+		{Id: "backup-0", SourceClusterId: "cid-777", CreatedAt: &timestamp.Timestamp{Seconds: 1625000000}},
+	}}
+	d := testDataWithTrigger("2021-07-02")
+	err := triggerRedisBackup(context.Background(), d, starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, starter.backups, 2)
+	require.Equal(t, "backup-2", d.Get("backup_id"))
+
+	// scheduled backups started before or after the requested one are not taken, even if they completed later
+	starter = &redisBackupStarter{concurrent: []*redis.Backup{
+		{Id: "scheduled-before", SourceClusterId: "cid-777", StartedAt: &timestamp.Timestamp{Seconds: 1625097000},
+			CreatedAt: &timestamp.Timestamp{Seconds: 1625098000}},
+		{Id: "scheduled-after", SourceClusterId: "cid-777", StartedAt: &timestamp.Timestamp{Seconds: 1625097610},
+			CreatedAt: &timestamp.Timestamp{Seconds: 1625097620}},
+	}}
+	d = testDataWithTrigger("2021-07-02")
+	require.NoError(t, triggerRedisBackup(context.Background(), d, starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.Equal(t, "backup-1", d.Get("backup_id"))
+
+	// no backup started since the operation
+	_, err = redisBackupIDStartedSince(context.Background(), starter, "cid-777", time.Unix(1625099000, 0))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "has no backups started since")
+
+	// backup_id is unknown in the plan starting a backup
+	diff, err := testRedisClusterDiff(t, oldRaw, func() map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		raw["backup_trigger"] = "2021-07-02"
+		return raw
+	}())
+	require.NoError(t, err)
+	require.True(t, diff.Attributes["backup_id"].NewComputed)
+
+	// unchanged or removed trigger doesn't start backup
+	starter = &redisBackupStarter{}
+	require.NoError(t, triggerRedisBackup(context.Background(), testDataWithTrigger("2021-07-01"), starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.NoError(t, triggerRedisBackup(context.Background(), testDataWithTrigger(""), starter, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.Empty(t, starter.backups)
+}
+
+type redisMaintenanceRescheduler struct {
+	redisHostsPagesLister
+	planned    *redis.MaintenanceOperation
+	reschedule []*redis.RescheduleMaintenanceRequest
+}
+
+func (f *redisMaintenanceRescheduler) Get(ctx context.Context, in *redis.GetClusterRequest, opts ...grpc.CallOption) (*redis.Cluster, error) {
+	return &redis.Cluster{Id: in.ClusterId, PlannedOperation: f.planned}, nil
+}
+
+func (f *redisMaintenanceRescheduler) RescheduleMaintenance(ctx context.Context, in *redis.RescheduleMaintenanceRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error) {
+	f.reschedule = append(f.reschedule, in)
+	return &ycoperation.Operation{Id: "op-reschedule", Description: "Reschedule maintenance in Redis cluster"}, nil
+}
+
+func TestRescheduleRedisMaintenance(t *testing.T) {
+	withReschedule := func(rescheduleType, delayedUntil string) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		if rescheduleType != "" {
+			raw["maintenance_reschedule"] = []interface{}{
+				map[string]interface{}{"type": rescheduleType, "delayed_until": delayedUntil},
+			}
+		}
+		return raw
+	}
+	planned := &redis.MaintenanceOperation{Info: "Upgrade Redis", DelayedUntil: &timestamp.Timestamp{Seconds: 1625097600}}
+
+	f := &redisMaintenanceRescheduler{planned: planned}
+	d := testRedisClusterUpdateData(t, testRedisClusterRaw(16), withReschedule("SPECIFIC_TIME", "2021-07-02T03:00:00Z"))
+	err := rescheduleRedisMaintenance(context.Background(), d, f, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, f.reschedule, 1)
+	require.Equal(t, "cid-777", f.reschedule[0].ClusterId)
+	require.Equal(t, redis.RescheduleMaintenanceRequest_SPECIFIC_TIME, f.reschedule[0].RescheduleType)
+	require.Equal(t, int64(1625194800), f.reschedule[0].DelayedUntil.Seconds)
+
+	f = &redisMaintenanceRescheduler{planned: planned}
+	d = testRedisClusterUpdateData(t, withReschedule("NEXT_AVAILABLE_WINDOW", ""), withReschedule("IMMEDIATE", ""))
+	require.NoError(t, rescheduleRedisMaintenance(context.Background(), d, f, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.Len(t, f.reschedule, 1)
+	require.Equal(t, redis.RescheduleMaintenanceRequest_IMMEDIATE, f.reschedule[0].RescheduleType)
+	require.Nil(t, f.reschedule[0].DelayedUntil)
+
+	// nothing is planned
+	f = &redisMaintenanceRescheduler{}
+	d = testRedisClusterUpdateData(t, testRedisClusterRaw(16), withReschedule("IMMEDIATE", ""))
+	require.NoError(t, rescheduleRedisMaintenance(context.Background(), d, f, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	require.Empty(t, f.reschedule)
+
+	// unchanged or removed block doesn't reschedule
+	f = &redisMaintenanceRescheduler{planned: planned}
+	for _, newRaw := range []map[string]interface{}{withReschedule("IMMEDIATE", ""), testRedisClusterRaw(16)} {
+		d = testRedisClusterUpdateData(t, withReschedule("IMMEDIATE", ""), newRaw)
+		require.NoError(t, rescheduleRedisMaintenance(context.Background(), d, f, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond))
+	}
+	require.Empty(t, f.reschedule)
+
+	d = testRedisClusterUpdateData(t, testRedisClusterRaw(16), withReschedule("SPECIFIC_TIME", ""))
+	err = rescheduleRedisMaintenance(context.Background(), d, f, &redisOperationsGetter{pollsUntilDone: 1}, time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'delayed_until' must be set for SPECIFIC_TIME")
+}
+
+func TestFlattenRedisShardHostCounts(t *testing.T) {
+	hosts := []*redis.Host{
+		{Name: "host-1", ShardName: "second"},
+		{Name: "host-2", ShardName: "first"},
+		{Name: "host-3", ShardName: "second"},
+		{Name: "host-4", ShardName: "first"},
+		{Name: "host-5", ShardName: "second"},
+	}
+
+	require.Equal(t, []map[string]interface{}{
+		{"name": "first", "host_count": 2},
+		{"name": "second", "host_count": 3},
+	}, flattenRedisShardHostCounts(hosts, true))
+
+	require.Equal(t, []map[string]interface{}{
+		{"name": "shard1", "host_count": 2},
+	}, flattenRedisShardHostCounts([]*redis.Host{{Name: "host-1", ShardName: "shard1"}, {Name: "host-2", ShardName: "shard1"}}, false))
+
+	require.Empty(t, flattenRedisShardHostCounts(nil, true))
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexMDBRedisCluster().Schema, map[string]interface{}{})
+	require.NoError(t, d.Set("shards", flattenRedisShardHostCounts(hosts, true)))
+	require.Equal(t, 3, d.Get("shards.1.host_count"))
+}
+
+type redisBlockingHostsLister struct {
+	redisHostsPagesLister
+}
+
+func (l *redisBlockingHostsLister) ListHosts(ctx context.Context, in *redis.ListClusterHostsRequest, opts ...grpc.CallOption) (*redis.ListClusterHostsResponse, error) {
+	<-ctx.Done()
+	return nil, status.FromContextError(ctx.Err()).Err()
+}
+
+func TestRedisReadContextCancelledWithProvider(t *testing.T) {
+	providerCtx, stopProvider := context.WithCancel(context.Background())
+	defer stopProvider()
+	config := &Config{contextWithClientTraceID: providerCtx}
+
+	d := schema.TestResourceDataRaw(t, resourceYandexMDBRedisCluster().Schema, testRedisClusterRaw(16))
+	ctx, cancel := redisReadContext(config, d)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now().Add(d.Timeout(schema.TimeoutRead)), deadline, time.Minute)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := listRedisClusterHosts(ctx, &redisBlockingHostsLister{}, "cid-777", false)
+		done <- err
+	}()
+
+	stopProvider()
+	select {
+	case err := <-done:
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context canceled")
+	case <-time.After(5 * time.Second):
+		t.Fatal("listing of hosts is not cancelled together with the provider")
+	}
+}
+
+func TestRedisVersionConfigDiffCustomize(t *testing.T) {
+	withVersion := func(version string, settings map[string]interface{}) map[string]interface{} {
+		raw := testRedisClusterRaw(16)
+		conf := raw["config"].([]interface{})[0].(map[string]interface{})
+		conf["version"] = version
+		for k, v := range settings {
+			conf[k] = v
+		}
+		return raw
+	}
+
+	// all settings of the config block are fields of the Redis config of every version in the API
+	for _, version := range redisSupportedVersions() {
+		require.ElementsMatch(t, redisConfigDriftFields, redisVersionConfigKeys[version], version)
+	}
+	require.Equal(t, []string{"5.0", "6.0"}, redisSupportedVersions())
+
+	_, err := testRedisClusterDiff(t, nil, withVersion("5.0", map[string]interface{}{"notify_keyspace_events": "Elg"}))
+	require.NoError(t, err)
+
+	_, err = testRedisClusterDiff(t, nil, withVersion("7.0", nil))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "config.0.version \"7.0\" of Redis Cluster \"redis-tf-name\" is not supported, supported versions are: `5.0`, `6.0`")
+
+	// a setting supported by newer versions only, e.g. of 7.0, the API has no such settings yet
+	defer func(keys map[string][]string) { redisVersionConfigKeys = keys }(redisVersionConfigKeys)
+	redisVersionConfigKeys = map[string][]string{
+		"5.0": {"timeout", "maxmemory_policy", "slowlog_log_slower_than", "slowlog_max_len", "databases"},
+		"7.0": {"timeout", "maxmemory_policy", "notify_keyspace_events", "slowlog_log_slower_than", "slowlog_max_len", "databases"},
+	}
+
+	_, err = testRedisClusterDiff(t, nil, withVersion("5.0", map[string]interface{}{"notify_keyspace_events": "Elg", "timeout": 100}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "settings notify_keyspace_events of Redis Cluster \"redis-tf-name\" are not supported by Redis 5.0")
+
+	// unchanged settings of an existing cluster come from the API
+	_, err = testRedisClusterDiff(t,
+		withVersion("5.0", map[string]interface{}{"notify_keyspace_events": "Elg"}),
+		withVersion("5.0", map[string]interface{}{"notify_keyspace_events": "Elg", "timeout": 100}))
+	require.NoError(t, err)
+}
+
+func TestRedisConfigDrift(t *testing.T) {
+	raw := testRedisClusterRaw(16)
+	raw["detect_config_drift"] = true
+
+	// slowlog_max_len is not declared, the state has the value read before
+	res := resourceYandexMDBRedisCluster()
+	state := schema.TestResourceDataRaw(t, res.Schema, raw)
+	state.SetId("cid-777")
+	read := func(slowlogMaxLen int64) map[string]interface{} {
+		return map[string]interface{}{
+			"password":                "passw0rd",
+			"version":                 "6.0",
+			"timeout":                 int64(0),
+			"maxmemory_policy":        "VOLATILE_LRU",
+			"notify_keyspace_events":  "",
+			"slowlog_log_slower_than": int64(10000),
+			"slowlog_max_len":         slowlogMaxLen,
+			"databases":               int64(16),
+		}
+	}
+	require.NoError(t, state.Set("config", []map[string]interface{}{read(10)}))
+
+	// the API changed slowlog_max_len outside of Terraform
+	conf := read(12)
+	drift := redisConfigDrift(state, conf)
+	require.Equal(t, map[string]string{"slowlog_max_len": "12"}, drift)
+	// the state takes the API values
+	require.Equal(t, int64(12), conf["slowlog_max_len"])
+
+	require.NoError(t, state.Set("config", []map[string]interface{}{conf}))
+	require.NoError(t, state.Set("config_drift", drift))
+
+	// the drift is shown in the plan, applying it sends nothing to the API
+	diff, err := res.Diff(state.State(), terraform.NewResourceConfigRaw(raw), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.Contains(t, diff.Attributes, "config_drift.slowlog_max_len")
+	require.NotContains(t, diff.Attributes, "config.0.slowlog_max_len")
+
+	d, err := schema.InternalMap(res.Schema).Data(state.State(), diff)
+	require.NoError(t, err)
+	require.False(t, redisClusterParamsChanged(d))
+
+	// without drift there is nothing to plan
+	require.NoError(t, state.Set("config_drift", map[string]string{}))
+	diff, err = res.Diff(state.State(), terraform.NewResourceConfigRaw(raw), nil)
+	require.NoError(t, err)
+	if diff != nil {
+		require.NotContains(t, diff.Attributes, "config_drift.slowlog_max_len")
+	}
+
+	// nothing was read before import
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+	require.Empty(t, redisConfigDrift(d, conf))
+}
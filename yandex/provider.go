@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/yandex-cloud/go-sdk/operation"
 )
 
 const (
@@ -106,6 +108,20 @@ func provider(emptyFolder bool) terraform.ResourceProvider {
 				Default:     defaultMaxRetries,
 				Description: descriptions["max_retries"],
 			},
+			"retry_base_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultExponentialBackoffBase.String(),
+				Description:  descriptions["retry_base_delay"],
+				ValidateFunc: validateParsableValue(time.ParseDuration),
+			},
+			"operation_poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      operation.DefaultPollInterval.String(),
+				Description:  descriptions["operation_poll_interval"],
+				ValidateFunc: validateParsableValue(time.ParseDuration),
+			},
 			"ymq_endpoint": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -164,7 +180,11 @@ func provider(emptyFolder bool) terraform.ResourceProvider {
 			"yandex_mdb_mysql_cluster":            dataSourceYandexMDBMySQLCluster(),
 			"yandex_mdb_sqlserver_cluster":        dataSourceYandexMDBSQLServerCluster(),
 			"yandex_mdb_postgresql_cluster":       dataSourceYandexMDBPostgreSQLCluster(),
+			"yandex_mdb_postgresql_database":      dataSourceYandexMDBPostgreSQLDatabase(),
+			"yandex_mdb_postgresql_user":          dataSourceYandexMDBPostgreSQLUser(),
 			"yandex_mdb_redis_cluster":            dataSourceYandexMDBRedisCluster(),
+			"yandex_mdb_redis_clusters":           dataSourceYandexMDBRedisClusters(),
+			"yandex_mdb_redis_resource_presets":   dataSourceYandexMDBRedisResourcePresets(),
 			"yandex_mdb_kafka_cluster":            dataSourceYandexMDBKafkaCluster(),
 			"yandex_mdb_elasticsearch_cluster":    dataSourceYandexMDBElasticsearchCluster(),
 			"yandex_message_queue":                dataSourceYandexMessageQueue(),
@@ -307,6 +327,12 @@ var descriptions = map[string]string{
 	"max_retries": "The maximum number of times an API request is being executed. \n" +
 		"If the API request still fails, an error is thrown.",
 
+	"retry_base_delay": "The base delay between retries of a failed API request, e.g. `100ms` or `1s`. \n" +
+		"The delay grows exponentially with each attempt. Default is " + defaultExponentialBackoffBase.String(),
+
+	"operation_poll_interval": "The delay between polls of a long running operation, e.g. `5s`. \n" +
+		"Increase it to make fewer API calls while waiting for operations. Default is " + operation.DefaultPollInterval.String(),
+
 	"storage_endpoint": "Yandex.Cloud storage service endpoint. Default is \n" + defaultStorageEndpoint,
 
 	"storage_access_key": "Yandex.Cloud storage service access key. \n" +
@@ -326,6 +352,16 @@ var descriptions = map[string]string{
 
 func providerConfigure(provider *schema.Provider, emptyFolder bool) schema.ConfigureFunc {
 	return func(d *schema.ResourceData) (interface{}, error) {
+		retryBaseDelay, err := time.ParseDuration(d.Get("retry_base_delay").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error while parsing retry_base_delay: %s", err)
+		}
+
+		operationPollInterval, err := time.ParseDuration(d.Get("operation_poll_interval").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error while parsing operation_poll_interval: %s", err)
+		}
+
 		config := Config{
 			Token:                          d.Get("token").(string),
 			ServiceAccountKeyFileOrContent: d.Get("service_account_key_file").(string),
@@ -336,6 +372,8 @@ func providerConfigure(provider *schema.Provider, emptyFolder bool) schema.Confi
 			Plaintext:                      d.Get("plaintext").(bool),
 			Insecure:                       d.Get("insecure").(bool),
 			MaxRetries:                     d.Get("max_retries").(int),
+			RetryBaseDelay:                 retryBaseDelay,
+			OperationPollInterval:          operationPollInterval,
 			StorageEndpoint:                d.Get("storage_endpoint").(string),
 			StorageAccessKey:               d.Get("storage_access_key").(string),
 			StorageSecretKey:               d.Get("storage_secret_key").(string),
@@ -12,6 +12,7 @@ import (
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
+	"github.com/yandex-cloud/go-sdk/operation"
 )
 
 const providerDefaultValueInsecure = false
@@ -125,6 +126,18 @@ func TestProviderDefaultValues(t *testing.T) {
 		t.Errorf("there is not default option 'Insecure' (%v), should be %v", conf.Plaintext, providerDefaultValueInsecure)
 	}
 
+	if conf.MaxRetries != defaultMaxRetries {
+		t.Errorf("there is not default option 'MaxRetries' (%v), should be %v", conf.MaxRetries, defaultMaxRetries)
+	}
+
+	if conf.RetryBaseDelay != defaultExponentialBackoffBase {
+		t.Errorf("there is not default option 'RetryBaseDelay' (%v), should be %v", conf.RetryBaseDelay, defaultExponentialBackoffBase)
+	}
+
+	if conf.OperationPollInterval != operation.DefaultPollInterval {
+		t.Errorf("there is not default option 'OperationPollInterval' (%v), should be %v", conf.OperationPollInterval, operation.DefaultPollInterval)
+	}
+
 	// restore OS env vars
 	if err := restoreEnvVars(saveEnvVariable); err != nil {
 		t.Fatal("failed to restore OS env vars:", envVars, "after test", t.Name(), " - error:", err)
@@ -19,6 +19,11 @@ const (
 	yandexMDBPostgreSQLClusterCreateTimeout = 30 * time.Minute
 	yandexMDBPostgreSQLClusterDeleteTimeout = 15 * time.Minute
 	yandexMDBPostgreSQLClusterUpdateTimeout = 60 * time.Minute
+	mdbPostgreSQLEngine                     = "postgresql"
+
+	// Sampling intervals of performance diagnostics accepted by API, in seconds
+	pgSamplingIntervalMin = 1
+	pgSamplingIntervalMax = 86400
 )
 
 func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
@@ -97,8 +102,9 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"pooling_mode": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateParsableValue(parsePostgreSQLPoolingMode),
 									},
 									"pool_discard": {
 										Type:     schema.TypeBool,
@@ -107,6 +113,10 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 								},
 							},
 						},
+						"pooling_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
 						"backup_window_start": {
 							Type:     schema.TypeList,
 							MaxItems: 1,
@@ -142,12 +152,14 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 										Computed: true,
 									},
 									"sessions_sampling_interval": {
-										Type:     schema.TypeInt,
-										Required: true,
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(pgSamplingIntervalMin, pgSamplingIntervalMax),
 									},
 									"statements_sampling_interval": {
-										Type:     schema.TypeInt,
-										Required: true,
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(pgSamplingIntervalMin, pgSamplingIntervalMax),
 									},
 								},
 							},
@@ -178,10 +190,17 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 							Computed:         true,
 							DiffSuppressFunc: generateMapSchemaDiffSuppressFunc(mdbPGSettingsFieldsInfo),
 							ValidateFunc:     generateMapSchemaValidateFunc(mdbPGSettingsFieldsInfo),
+							ConflictsWith:    []string{"config.0.postgresql_config_json"},
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
 						},
+						"postgresql_config_json": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ValidateFunc:  validatePGSettingsJSON,
+							ConflictsWith: []string{"config.0.postgresql_config"},
+						},
 					},
 				},
 			},
@@ -267,9 +286,10 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 							},
 						},
 						"conn_limit": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(pgUserConnLimitUnlimited),
 						},
 						"settings": {
 							Type:             schema.TypeMap,
@@ -321,8 +341,9 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 							Computed: true,
 						},
 						"priority": {
-							Type:     schema.TypeInt,
-							Optional: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
 						},
 						"replication_source_name": {
 							Type:     schema.TypeString,
@@ -414,7 +435,7 @@ func resourceYandexMDBPostgreSQLCluster() *schema.Resource {
 						},
 						"hour": {
 							Type:         schema.TypeInt,
-							ValidateFunc: validation.IntBetween(1, 24),
+							ValidateFunc: validation.IntBetween(0, 23),
 							Optional:     true,
 						},
 					},
@@ -460,6 +481,11 @@ func resourceYandexMDBPostgreSQLClusterRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
+	// API doesn't know anything about postgresql_config_json, so keep the configured value
+	if len(pgClusterConf) > 0 {
+		pgClusterConf[0].(map[string]interface{})["postgresql_config_json"] = d.Get("config.0.postgresql_config_json")
+	}
+
 	if err := d.Set("config", pgClusterConf); err != nil {
 		return err
 	}
@@ -846,6 +872,7 @@ func updatePGClusterParams(d *schema.ResourceData, meta interface{}) error {
 
 	if updateFieldConfigName != "" {
 		mdbPGUpdateFieldsMap["config.0.postgresql_config"] = "config_spec." + updateFieldConfigName
+		mdbPGUpdateFieldsMap["config.0.postgresql_config_json"] = "config_spec." + updateFieldConfigName
 	}
 
 	onDone := []func(){}
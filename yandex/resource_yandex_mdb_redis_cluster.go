@@ -8,16 +8,28 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+	ycoperation "github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-sdk/operation"
+	"github.com/yandex-cloud/go-sdk/pkg/sdkerrors"
+	"github.com/yandex-cloud/go-sdk/sdkresolvers"
 )
 
 const (
 	yandexMDBRedisClusterDefaultTimeout = 15 * time.Minute
 	yandexMDBRedisClusterUpdateTimeout  = 60 * time.Minute
 	defaultMDBPageSize                  = 1000
+	redisOperationProgressInterval      = 30 * time.Second
+	redisOperationNotFoundRetries       = 3
+	redisUpdateConflictRetries          = 3
+	mdbRedisEngine                      = "redis"
 )
 
 func resourceYandexMDBRedisCluster() *schema.Resource {
@@ -27,7 +39,7 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 		Update: resourceYandexMDBRedisClusterUpdate,
 		Delete: resourceYandexMDBRedisClusterDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceYandexMDBRedisClusterImportState,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -36,6 +48,23 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 			Delete: schema.DefaultTimeout(yandexMDBRedisClusterDefaultTimeout),
 		},
 
+		CustomizeDiff: customdiff.All(
+			redisDiskSizeDiffCustomize,
+			redisResourcePresetDiffCustomize,
+			redisResourcePresetFamilyDiffCustomize,
+			redisEnvironmentDiffCustomize,
+			redisTLSDiffCustomize,
+			redisHostSubnetsDiffCustomize,
+			redisHostZonesDiffCustomize,
+			redisShardHostsDiffCustomize,
+			redisHostRedundancyDiffCustomize,
+			redisPasswordDiffCustomize,
+			redisDatabasesDiffCustomize,
+			redisVersionConfigDiffCustomize,
+			redisConfigDriftDiffCustomize,
+			redisBackupIDDiffCustomize,
+		),
+
 		SchemaVersion: 0,
 
 		Schema: map[string]*schema.Schema{
@@ -61,9 +90,10 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"password": {
-							Type:      schema.TypeString,
-							Required:  true,
-							Sensitive: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validateRedisPassword,
 						},
 						"timeout": {
 							Type:     schema.TypeInt,
@@ -76,19 +106,22 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 							Computed: true,
 						},
 						"notify_keyspace_events": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateRedisNotifyKeyspaceEvents,
 						},
 						"slowlog_log_slower_than": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(-1),
 						},
 						"slowlog_max_len": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(0),
 						},
 						"databases": {
 							Type:     schema.TypeInt,
@@ -117,9 +150,10 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 							Required: true,
 						},
 						"disk_type_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice(redisDiskTypes, false),
 						},
 					},
 				},
@@ -147,6 +181,14 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -172,6 +214,28 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
+			"config_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"detect_config_drift": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"config_drift": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"password_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"effective_config_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"folder_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -196,6 +260,144 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 				Set:      schema.HashString,
 				Optional: true,
 			},
+			"reconcile_by_name": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"allow_partial_hosts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"auto_rebalance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"host_operation_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateParsableValue(time.ParseDuration),
+			},
+			"failover_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"backup_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"backup_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fqdns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"master_fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"replica_fqdns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"shard_master_fqdns": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"shard_replica_fqdns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"shard_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fqdns": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"allow_environment_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"allow_no_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"require_host_redundancy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_health": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"restore": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"maintenance_reschedule": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"IMMEDIATE", "NEXT_AVAILABLE_WINDOW", "SPECIFIC_TIME"}, false),
+						},
+						"delayed_until": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.ValidateRFC3339TimeString,
+						},
+					},
+				},
+			},
+			"planned_operation": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"info": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"delayed_until": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"maintenance_window": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -215,7 +417,7 @@ func resourceYandexMDBRedisCluster() *schema.Resource {
 						},
 						"hour": {
 							Type:         schema.TypeInt,
-							ValidateFunc: validation.IntBetween(1, 24),
+							ValidateFunc: validation.IntBetween(0, 23),
 							Optional:     true,
 						},
 					},
@@ -237,36 +439,55 @@ func resourceYandexMDBRedisClusterCreate(d *schema.ResourceData, meta interface{
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
 
-	op, err := config.sdk.WrapOperation(config.sdk.MDB().Redis().Cluster().Create(ctx, req))
-	if err != nil {
-		return fmt.Errorf("Error while requesting API to create Redis Cluster: %s", err)
+	var op *operation.Operation
+	if backupID, ok := d.GetOk("restore.0.backup_id"); ok {
+		op, err = config.sdk.WrapOperation(config.sdk.MDB().Redis().Cluster().Restore(ctx, prepareRestoreRedisRequest(req, backupID.(string))))
+		if err != nil {
+			return fmt.Errorf("Error while requesting API to create Redis Cluster %q from backup %q: %s", req.Name, backupID, redisErrorWithRequestID(err))
+		}
+	} else {
+		op, err = config.sdk.WrapOperation(config.sdk.MDB().Redis().Cluster().Create(ctx, req))
+		if err != nil {
+			return fmt.Errorf("Error while requesting API to create Redis Cluster %q: %s", req.Name, redisErrorWithRequestID(err))
+		}
 	}
 
 	protoMetadata, err := op.Metadata()
 	if err != nil {
-		return fmt.Errorf("Error while get redis create operation metadata: %s", err)
+		return fmt.Errorf("Error while getting operation metadata to create Redis Cluster %q: %s", req.Name, err)
 	}
 
-	md, ok := protoMetadata.(*redis.CreateClusterMetadata)
-	if !ok {
-		return fmt.Errorf("Could not get Cluster ID from create operation metadata")
+	switch md := protoMetadata.(type) {
+	case *redis.CreateClusterMetadata:
+		d.SetId(md.ClusterId)
+	case *redis.RestoreClusterMetadata:
+		d.SetId(md.ClusterId)
+	default:
+		return fmt.Errorf("Could not get ID of Redis Cluster %q from create operation metadata", req.Name)
 	}
 
-	d.SetId(md.ClusterId)
-
-	err = op.Wait(ctx)
+	err = waitRedisOperation(ctx, op, config.operationPollInterval())
 	if err != nil {
-		return fmt.Errorf("Error while waiting for operation to create Redis Cluster: %s", err)
+		return fmt.Errorf("Error while waiting for operation to create Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
 
 	if _, err := op.Response(); err != nil {
-		return fmt.Errorf("Redis Cluster creation failed: %s", err)
+		return fmt.Errorf("Creation of Redis Cluster %q failed: %s", d.Id(), redisErrorWithRequestID(err))
 	}
 
-	mw, err := expandRedisMaintenanceWindow(d)
+	if d.Get("wait_for_health").(bool) {
+		err = waitRedisClusterAlive(ctx, config.sdk.MDB().Redis().Cluster(), d.Id(), config.operationPollInterval())
+	} else {
+		err = checkRedisClusterHealth(ctx, config.sdk.MDB().Redis().Cluster(), d.Id())
+	}
 	if err != nil {
 		return err
 	}
+
+	mw, err := expandRedisMaintenanceWindow(d)
+	if err != nil {
+		return fmt.Errorf("Error while expanding \"maintenance_window\" of Redis Cluster %q: %s", d.Id(), err)
+	}
 	if mw != nil {
 		err = updateRedisMaintenanceWindow(ctx, config, d, mw)
 		if err != nil {
@@ -277,37 +498,90 @@ func resourceYandexMDBRedisClusterCreate(d *schema.ResourceData, meta interface{
 	return resourceYandexMDBRedisClusterRead(d, meta)
 }
 
+// Fails if the cluster is known to be broken, so that it isn't reported as successfully created.
+// Health may be not yet known right after creation, it is not an error.
+func checkRedisClusterHealth(ctx context.Context, client ReducedRedisClusterServiceClient, clusterID string) error {
+	cluster, err := client.Get(ctx, &redis.GetClusterRequest{
+		ClusterId: clusterID,
+	})
+	if err != nil {
+		return fmt.Errorf("Error while getting Redis Cluster %q after creation: %s", clusterID, err)
+	}
+
+	switch cluster.GetHealth() {
+	case redis.Cluster_ALIVE:
+		return nil
+	case redis.Cluster_HEALTH_UNKNOWN:
+		log.Printf("[WARN] Health of Redis Cluster %q is unknown after creation", clusterID)
+		return nil
+	default:
+		return fmt.Errorf("Redis Cluster %q is created, but it is not healthy: health is %s", clusterID, cluster.GetHealth().String())
+	}
+}
+
+// Polls the cluster until its health is ALIVE, e.g. until all replicas are initialized after creation.
+// It gives up when the cluster is DEAD, which it doesn't recover from by itself, or when ctx is done,
+// so it is bounded by the create timeout.
+func waitRedisClusterAlive(ctx context.Context, client ReducedRedisClusterServiceClient, clusterID string, pollInterval time.Duration) error {
+	for {
+		cluster, err := client.Get(ctx, &redis.GetClusterRequest{
+			ClusterId: clusterID,
+		})
+		if err != nil {
+			return fmt.Errorf("Error while getting Redis Cluster %q after creation: %s", clusterID, err)
+		}
+
+		health := cluster.GetHealth()
+		switch health {
+		case redis.Cluster_ALIVE:
+			return nil
+		case redis.Cluster_DEAD:
+			return fmt.Errorf("Redis Cluster %q is created, but it is not healthy: health is %s", clusterID, health.String())
+		}
+		log.Printf("[DEBUG] Waiting for Redis Cluster %q to become ALIVE, health is %s", clusterID, health.String())
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Redis Cluster %q is created, but it didn't become ALIVE in time: health is %s", clusterID, health.String())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
 func prepareCreateRedisRequest(d *schema.ResourceData, meta *Config) (*redis.CreateClusterRequest, error) {
+	// the cluster has no ID yet, errors refer to it by name
+	name := d.Get("name").(string)
+
 	labels, err := expandLabels(d.Get("labels"))
 
 	if err != nil {
-		return nil, fmt.Errorf("Error while expanding labels on Redis Cluster create: %s", err)
+		return nil, fmt.Errorf("Error while expanding \"labels\" on create of Redis Cluster %q: %s", name, err)
 	}
 
 	folderID, err := getFolderID(d, meta)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting folder ID while creating Redis Cluster: %s", err)
+		return nil, fmt.Errorf("Error while getting \"folder_id\" on create of Redis Cluster %q: %s", name, err)
 	}
 
 	hosts, err := expandRedisHosts(d)
 	if err != nil {
-		return nil, fmt.Errorf("Error while expanding hosts on Redis Cluster create: %s", err)
+		return nil, fmt.Errorf("Error while expanding \"host\" on create of Redis Cluster %q: %s", name, err)
 	}
 
 	e := d.Get("environment").(string)
 	env, err := parseRedisEnv(e)
 	if err != nil {
-		return nil, fmt.Errorf("Error resolving environment while creating Redis Cluster: %s", err)
+		return nil, fmt.Errorf("Error while resolving \"environment\" on create of Redis Cluster %q: %s", name, err)
 	}
 
 	conf, version, err := expandRedisConfig(d)
 	if err != nil {
-		return nil, fmt.Errorf("Error while expanding config while creating Redis Cluster: %s", err)
+		return nil, fmt.Errorf("Error while expanding \"config\" on create of Redis Cluster %q: %s", name, err)
 	}
 
 	resources, err := expandRedisResources(d)
 	if err != nil {
-		return nil, fmt.Errorf("Error while expanding resources on Redis Cluster create: %s", err)
+		return nil, fmt.Errorf("Error while expanding \"resources\" on create of Redis Cluster %q: %s", name, err)
 	}
 
 	configSpec := &redis.ConfigSpec{
@@ -334,28 +608,56 @@ func prepareCreateRedisRequest(d *schema.ResourceData, meta *Config) (*redis.Cre
 	return &req, nil
 }
 
+// The restored cluster is placed as declared in the configuration, e.g. into another network
+// than the source cluster of the backup, nothing is copied from the source cluster.
+// Sharding is not a part of the request, it is the same as of the source cluster.
+func prepareRestoreRedisRequest(req *redis.CreateClusterRequest, backupID string) *redis.RestoreClusterRequest {
+	return &redis.RestoreClusterRequest{
+		BackupId:         backupID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Labels:           req.Labels,
+		Environment:      req.Environment,
+		ConfigSpec:       req.ConfigSpec,
+		HostSpecs:        req.HostSpecs,
+		NetworkId:        req.NetworkId,
+		FolderId:         req.FolderId,
+		SecurityGroupIds: req.SecurityGroupIds,
+		TlsEnabled:       req.TlsEnabled,
+	}
+}
+
+// Reads are bounded by the read timeout and are cancelled together with the provider, e.g. on its shutdown.
+func redisReadContext(config *Config, d *schema.ResourceData) (context.Context, context.CancelFunc) {
+	return config.ContextWithTimeout(d.Timeout(schema.TimeoutRead))
+}
+
 func resourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutRead))
+	ctx, cancel := redisReadContext(config, d)
 	defer cancel()
 
 	cluster, err := config.sdk.MDB().Redis().Cluster().Get(ctx, &redis.GetClusterRequest{
 		ClusterId: d.Id(),
 	})
+	if err != nil && isStatusWithCode(err, codes.NotFound) && d.Get("reconcile_by_name").(bool) {
+		if c, rerr := findRedisClusterByName(ctx, config, d); rerr == nil {
+			cluster, err = c, nil
+		} else {
+			log.Printf("[DEBUG] Could not find Redis Cluster %q by name: %s", d.Get("name").(string), rerr)
+		}
+	}
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("Cluster %q", d.Get("name").(string)))
 	}
 
-	hosts, err := listRedisHosts(ctx, config, d)
+	hosts, err := listRedisClusterHosts(ctx, config.sdk.MDB().Redis().Cluster(), d.Id(), d.Get("allow_partial_hosts").(bool))
 	if err != nil {
 		return err
 	}
 
-	createdAt, err := getTimestamp(cluster.CreatedAt)
-	if err != nil {
-		return err
-	}
+	createdAt := getTimestampOrEmpty(cluster.CreatedAt, "created_at")
 
 	d.Set("created_at", createdAt)
 	d.Set("name", cluster.Name)
@@ -367,6 +669,13 @@ func resourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{})
 	d.Set("description", cluster.Description)
 	d.Set("sharded", cluster.Sharded)
 	d.Set("tls_enabled", cluster.TlsEnabled)
+	d.Set("config_fingerprint", redisConfigFingerprint(cluster.Config))
+
+	effectiveConfig, err := redisEffectiveConfigJSON(cluster.Config)
+	if err != nil {
+		return err
+	}
+	d.Set("effective_config_json", effectiveConfig)
 
 	resources, err := flattenRedisResources(cluster.Config.Resources)
 	if err != nil {
@@ -378,20 +687,28 @@ func resourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{})
 	if v, ok := d.GetOk("config.0.password"); ok {
 		password = v.(string)
 	}
+	// API never returns the password, so only whether it is known to Terraform can be reported
+	d.Set("password_set", password != "")
 
-	err = d.Set("config", []map[string]interface{}{
-		{
-			"timeout":                 conf.timeout,
-			"maxmemory_policy":        conf.maxmemoryPolicy,
-			"notify_keyspace_events":  conf.notifyKeyspaceEvents,
-			"slowlog_log_slower_than": conf.slowlogLogSlowerThan,
-			"slowlog_max_len":         conf.slowlogMaxLen,
-			"databases":               conf.databases,
-			"version":                 conf.version,
-			"password":                password,
-		},
-	})
-	if err != nil {
+	confMap := map[string]interface{}{
+		"timeout":                 conf.timeout,
+		"maxmemory_policy":        conf.maxmemoryPolicy,
+		"notify_keyspace_events":  conf.notifyKeyspaceEvents,
+		"slowlog_log_slower_than": conf.slowlogLogSlowerThan,
+		"slowlog_max_len":         conf.slowlogMaxLen,
+		"databases":               conf.databases,
+		"version":                 conf.version,
+		"password":                password,
+	}
+	drift := map[string]string{}
+	if d.Get("detect_config_drift").(bool) {
+		drift = redisConfigDrift(d, confMap)
+	}
+
+	if err := d.Set("config", []map[string]interface{}{confMap}); err != nil {
+		return err
+	}
+	if err := d.Set("config_drift", drift); err != nil {
 		return err
 	}
 
@@ -416,7 +733,11 @@ func resourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	if err := d.Set("security_group_ids", cluster.SecurityGroupIds); err != nil {
+	if err := setRedisHostEndpoints(d, hs); err != nil {
+		return err
+	}
+
+	if err := d.Set("security_group_ids", flattenSecurityGroupIds(cluster.SecurityGroupIds)); err != nil {
 		return err
 	}
 
@@ -425,17 +746,42 @@ func resourceYandexMDBRedisClusterRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	return d.Set("labels", cluster.Labels)
+	if err := d.Set("planned_operation", flattenRedisPlannedOperation(cluster.PlannedOperation)); err != nil {
+		return err
+	}
+
+	return d.Set("labels", filterMDBSystemLabels(cluster.Labels, d.Get("labels").(map[string]interface{})))
+}
+
+// Looks up the cluster with the same name in the same folder and adopts its ID,
+// so a cluster recreated out of band stays in the state.
+func findRedisClusterByName(ctx context.Context, config *Config, d *schema.ResourceData) (*redis.Cluster, error) {
+	name := d.Get("name").(string)
+	clusterID, err := resolveObjectIDByNameAndFolderID(ctx, config, name, d.Get("folder_id").(string), sdkresolvers.RedisClusterResolver)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := config.sdk.MDB().Redis().Cluster().Get(ctx, &redis.GetClusterRequest{
+		ClusterId: clusterID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[WARN] Redis Cluster %q was not found by ID %q, adopting cluster %q with the same name", name, d.Id(), clusterID)
+	d.SetId(clusterID)
+	return cluster, nil
 }
 
 func resourceYandexMDBRedisClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 	d.Partial(true)
 
 	if d.HasChange("resources.0.disk_type_id") {
-		return fmt.Errorf("Changing disk_type_id is not supported for Redis Cluster. Id: %v", d.Id())
+		return fmt.Errorf("Changing \"resources.0.disk_type_id\" of Redis Cluster %q is not supported", d.Id())
 	}
 
-	if d.HasChange("name") || d.HasChange("labels") || d.HasChange("description") || d.HasChange("resources") || d.HasChange("config") || d.HasChange("security_group_ids") {
+	if redisClusterParamsChanged(d) {
 		if err := updateRedisClusterParams(d, meta); err != nil {
 			return err
 		}
@@ -447,43 +793,270 @@ func resourceYandexMDBRedisClusterUpdate(d *schema.ResourceData, meta interface{
 		}
 	}
 
-	d.Partial(false)
-	return resourceYandexMDBRedisClusterRead(d, meta)
-}
+	if d.HasChange("failover_trigger") {
+		config := meta.(*Config)
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
 
-func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
-	req := &redis.UpdateClusterRequest{
-		ClusterId: d.Id(),
-		UpdateMask: &field_mask.FieldMask{
-			Paths: []string{},
-		},
+		if err := triggerRedisFailover(ctx, d, config.sdk.MDB().Redis().Cluster(), config.sdk.Operation(), config.operationPollInterval()); err != nil {
+			return err
+		}
+		d.SetPartial("failover_trigger")
 	}
-	onDone := []func(){}
-
-	if d.HasChange("name") {
-		req.Name = d.Get("name").(string)
-		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "name")
 
-		onDone = append(onDone, func() {
-			d.SetPartial("name")
-		})
-	}
+	if d.HasChange("maintenance_reschedule") {
+		config := meta.(*Config)
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
 
-	if d.HasChange("labels") {
-		labelsProp, err := expandLabels(d.Get("labels"))
-		if err != nil {
+		if err := rescheduleRedisMaintenance(ctx, d, config.sdk.MDB().Redis().Cluster(), config.sdk.Operation(), config.operationPollInterval()); err != nil {
 			return err
 		}
+		d.SetPartial("maintenance_reschedule")
+	}
 
-		req.Labels = labelsProp
-		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "labels")
+	if d.HasChange("backup_trigger") {
+		config := meta.(*Config)
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
 
-		onDone = append(onDone, func() {
-			d.SetPartial("labels")
-		})
+		if err := triggerRedisBackup(ctx, d, config.sdk.MDB().Redis().Cluster(), config.sdk.Operation(), config.operationPollInterval()); err != nil {
+			return err
+		}
+		d.SetPartial("backup_trigger")
+		d.SetPartial("backup_id")
 	}
 
-	if d.HasChange("description") {
+	d.Partial(false)
+	return resourceYandexMDBRedisClusterRead(d, meta)
+}
+
+// Starts failover of the master when failover_trigger is changed to a non-empty value.
+func triggerRedisFailover(ctx context.Context, d *schema.ResourceData, client ReducedRedisClusterServiceClient, opClient operation.Client, pollInterval time.Duration) error {
+	if !d.HasChange("failover_trigger") || d.Get("failover_trigger").(string) == "" {
+		return nil
+	}
+
+	protoOp, err := client.StartFailover(ctx, &redis.StartClusterFailoverRequest{
+		ClusterId: d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error while requesting API to start failover of Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	op := operation.New(opClient, protoOp)
+	err = waitRedisOperation(ctx, op, pollInterval)
+	if err != nil {
+		return fmt.Errorf("Error while waiting for operation to start failover of Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	if _, err := op.Response(); err != nil {
+		return fmt.Errorf("Failover of Redis Cluster %q failed: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+	return nil
+}
+
+// Reschedules the planned maintenance operation of the cluster when maintenance_reschedule is changed.
+// Nothing is done if no maintenance is planned.
+func rescheduleRedisMaintenance(ctx context.Context, d *schema.ResourceData, client ReducedRedisClusterServiceClient, opClient operation.Client, pollInterval time.Duration) error {
+	if !d.HasChange("maintenance_reschedule") {
+		return nil
+	}
+	req, err := expandRedisMaintenanceReschedule(d)
+	if err != nil {
+		return fmt.Errorf("Error while expanding \"maintenance_reschedule\" of Redis Cluster %q: %s", d.Id(), err)
+	}
+	if req == nil {
+		return nil
+	}
+
+	cluster, err := client.Get(ctx, &redis.GetClusterRequest{
+		ClusterId: d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error while getting Redis Cluster %q to reschedule maintenance: %s", d.Id(), err)
+	}
+	if cluster.PlannedOperation == nil {
+		log.Printf("[DEBUG] No maintenance is planned for Redis Cluster %q, nothing to reschedule", d.Id())
+		return nil
+	}
+
+	protoOp, err := client.RescheduleMaintenance(ctx, req)
+	if err != nil {
+		return fmt.Errorf("Error while requesting API to reschedule maintenance of Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	op := operation.New(opClient, protoOp)
+	err = waitRedisOperation(ctx, op, pollInterval)
+	if err != nil {
+		return fmt.Errorf("Error while waiting for operation to reschedule maintenance of Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	if _, err := op.Response(); err != nil {
+		return fmt.Errorf("Rescheduling maintenance of Redis Cluster %q failed: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+	return nil
+}
+
+// Starts a backup of the cluster when backup_trigger is changed to a non-empty value
+// and stores ID of the created backup in backup_id.
+func triggerRedisBackup(ctx context.Context, d *schema.ResourceData, client ReducedRedisClusterServiceClient, opClient operation.Client, pollInterval time.Duration) error {
+	if !d.HasChange("backup_trigger") || d.Get("backup_trigger").(string) == "" {
+		return nil
+	}
+
+	protoOp, err := client.Backup(ctx, &redis.BackupClusterRequest{
+		ClusterId: d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error while requesting API to backup Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	op := operation.New(opClient, protoOp)
+	err = waitRedisOperation(ctx, op, pollInterval)
+	if err != nil {
+		return fmt.Errorf("Error while waiting for operation to backup Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	if _, err := op.Response(); err != nil {
+		return fmt.Errorf("Backup of Redis Cluster %q failed: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+
+	backupID, err := redisBackupIDStartedSince(ctx, client, d.Id(), protoOp.GetCreatedAt().AsTime())
+	if err != nil {
+		return err
+	}
+	return d.Set("backup_id", backupID)
+}
+
+// A new backup_trigger starts a backup on apply, so backup_id isn't known until then.
+func redisBackupIDDiffCustomize(rdiff *schema.ResourceDiff, _ interface{}) error {
+	if rdiff.Id() == "" || !rdiff.HasChange("backup_trigger") || rdiff.Get("backup_trigger").(string) == "" {
+		return nil
+	}
+	return rdiff.SetNewComputed("backup_id")
+}
+
+// The backup operation doesn't report the created backup, so it is the first backup of the cluster started since
+// the operation was created. Backups started before, e.g. scheduled ones, may complete later and are not taken.
+func redisBackupIDStartedSince(ctx context.Context, client ReducedRedisClusterServiceClient, clusterID string, since time.Time) (string, error) {
+	var first *redis.Backup
+	var firstStartedAt time.Time
+	pageToken := ""
+	for {
+		resp, err := client.ListBackups(ctx, &redis.ListClusterBackupsRequest{
+			ClusterId: clusterID,
+			PageSize:  defaultMDBPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return "", fmt.Errorf("Error while getting list of backups of Redis Cluster %q: %s", clusterID, err)
+		}
+
+		for _, b := range resp.Backups {
+			startedAt := b.GetCreatedAt().AsTime()
+			if b.StartedAt != nil {
+				startedAt = b.GetStartedAt().AsTime()
+			}
+			if startedAt.Before(since) {
+				continue
+			}
+			if first == nil || startedAt.Before(firstStartedAt) {
+				first, firstStartedAt = b, startedAt
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	if first == nil {
+		return "", fmt.Errorf("Backup of Redis Cluster %q is done, but the cluster has no backups started since %s",
+			clusterID, since.Format(time.RFC3339))
+	}
+	return first.Id, nil
+}
+
+// Params of the cluster updated by updateRedisClusterParams.
+var redisClusterParams = []string{"name", "labels", "description", "resources", "config", "security_group_ids", "maintenance_window"}
+
+func redisClusterParamsChanged(d *schema.ResourceData) bool {
+	for _, key := range redisClusterParams {
+		if d.HasChange(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
+	req, onDone, err := prepareUpdateRedisClusterParamsRequest(d, meta)
+	if err != nil {
+		return err
+	}
+	if len(req.UpdateMask.Paths) == 0 {
+		return nil
+	}
+
+	// labels are replaced as a whole, keep the ones managed by the cloud
+	if d.HasChange("labels") {
+		cluster, err := getRedisCluster(d, meta)
+		if err != nil {
+			return err
+		}
+		oldLabels, _ := d.GetChange("labels")
+		refreshRedisUpdateRequest(req, cluster, oldLabels.(map[string]interface{}))
+	}
+
+	err = makeRedisClusterUpdateRequest(req, d, meta)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range onDone {
+		f()
+	}
+	return nil
+}
+
+// Builds the request updating the changed cluster params, each of them is set with its update mask path,
+// so that the param is updated even to its empty value, e.g. a cleared description.
+// The returned functions mark the params as saved once the request is done.
+func prepareUpdateRedisClusterParamsRequest(d *schema.ResourceData, meta interface{}) (*redis.UpdateClusterRequest, []func(), error) {
+	req := &redis.UpdateClusterRequest{
+		ClusterId: d.Id(),
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{},
+		},
+	}
+	onDone := []func(){}
+
+	if d.HasChange("name") {
+		req.Name = d.Get("name").(string)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "name")
+
+		onDone = append(onDone, func() {
+			d.SetPartial("name")
+		})
+	}
+
+	if d.HasChange("labels") {
+		labelsProp, err := expandLabels(d.Get("labels"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error while expanding \"labels\" of Redis Cluster %q: %s", d.Id(), err)
+		}
+
+		req.Labels = labelsProp
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "labels")
+
+		onDone = append(onDone, func() {
+			d.SetPartial("labels")
+		})
+	}
+
+	if d.HasChange("description") {
 		req.Description = d.Get("description").(string)
 		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "description")
 
@@ -493,9 +1066,15 @@ func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("resources") {
+		if d.HasChange("resources.0.resource_preset_id") {
+			if err := checkRedisResourcePresetZones(d, meta); err != nil {
+				return nil, nil, err
+			}
+		}
+
 		res, err := expandRedisResources(d)
 		if err != nil {
-			return err
+			return nil, nil, fmt.Errorf("Error while expanding \"resources\" of Redis Cluster %q: %s", d.Id(), err)
 		}
 
 		if req.ConfigSpec == nil {
@@ -512,23 +1091,30 @@ func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
 
 	if d.HasChange("config") {
 		if d.HasChange("config.0.version") {
-			return fmt.Errorf("Version update for Redis is not supported")
+			return nil, nil, fmt.Errorf("Changing \"config.0.version\" of Redis Cluster %q is not supported", d.Id())
 		}
 		conf, version, err := expandRedisConfig(d)
 		if err != nil {
-			return err
+			return nil, nil, fmt.Errorf("Error while expanding \"config\" of Redis Cluster %q: %s", d.Id(), err)
 		}
 
 		if req.ConfigSpec == nil {
 			req.ConfigSpec = &redis.ConfigSpec{}
 		}
 
+		// Only the changed settings are sent, so that settings changed by the API in the meantime are kept.
+		fields := changedRedisConfigFields(d)
 		req.ConfigSpec.RedisSpec = *conf
+		keepRedisConfigFields(req.ConfigSpec, fields)
+		var prefix string
 		switch version {
 		case "5.0":
-			req.UpdateMask.Paths = append(req.UpdateMask.Paths, "config_spec.redis_config_5_0")
+			prefix = "config_spec.redis_config_5_0"
 		case "6.0":
-			req.UpdateMask.Paths = append(req.UpdateMask.Paths, "config_spec.redis_config_6_0")
+			prefix = "config_spec.redis_config_6_0"
+		}
+		for _, field := range fields {
+			req.UpdateMask.Paths = append(req.UpdateMask.Paths, prefix+"."+field)
 		}
 
 		onDone = append(onDone, func() {
@@ -550,7 +1136,7 @@ func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChange("maintenance_window") {
 		mw, err := expandRedisMaintenanceWindow(d)
 		if err != nil {
-			return err
+			return nil, nil, fmt.Errorf("Error while expanding \"maintenance_window\" of Redis Cluster %q: %s", d.Id(), err)
 		}
 		req.MaintenanceWindow = mw
 		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "maintenance_window")
@@ -560,23 +1146,92 @@ func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
 		})
 	}
 
-	err := makeRedisClusterUpdateRequest(req, d, meta)
+	return req, onDone, nil
+}
+
+// Fails early if the new resource preset isn't offered in some zone of the cluster hosts, rather than in the middle
+// of the update. It is best-effort: if the preset or the hosts can't be fetched, the update goes on as is.
+func checkRedisResourcePresetZones(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	ctx, cancel := redisReadContext(config, d)
+	defer cancel()
+
+	presetID := d.Get("resources.0.resource_preset_id").(string)
+	presets, err := getRedisResourcePresets(config)
 	if err != nil {
-		return err
+		log.Printf("[WARN] Skipping zone check of Redis resource preset %q: %s", presetID, err)
+		return nil
+	}
+	preset := findRedisResourcePreset(presets, presetID)
+	if preset == nil {
+		log.Printf("[WARN] Skipping zone check of Redis resource preset %q: preset not found", presetID)
+		return nil
 	}
 
-	for _, f := range onDone {
-		f()
+	hosts, err := listRedisHosts(ctx, config, d)
+	if err != nil {
+		log.Printf("[WARN] Skipping zone check of Redis resource preset %q: %s", presetID, err)
+		return nil
+	}
+
+	zones := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		zones = append(zones, h.ZoneId)
+	}
+
+	if zone, ok := redisZoneWithoutPreset(preset, zones); ok {
+		return fmt.Errorf("Resource preset %q is not available in zone %q of Redis Cluster %q hosts", presetID, zone, d.Id())
 	}
 	return nil
 }
 
-func updateRedisClusterHosts(d *schema.ResourceData, meta interface{}) error {
+// Returns the first of zones in which the preset is not offered.
+func redisZoneWithoutPreset(preset *redis.ResourcePreset, zones []string) (string, bool) {
+	offered := map[string]bool{}
+	for _, z := range preset.ZoneIds {
+		offered[z] = true
+	}
+
+	for _, z := range zones {
+		if !offered[z] {
+			return z, true
+		}
+	}
+	return "", false
+}
+
+func getRedisCluster(d *schema.ResourceData, meta interface{}) (*redis.Cluster, error) {
 	config := meta.(*Config)
-	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutRead))
+
+	ctx, cancel := redisReadContext(config, d)
 	defer cancel()
 
-	sharded := d.Get("sharded").(bool)
+	cluster, err := config.sdk.MDB().Redis().Cluster().Get(ctx, &redis.GetClusterRequest{
+		ClusterId: d.Id(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error while getting Redis Cluster %q: %s", d.Id(), err)
+	}
+	return cluster, nil
+}
+
+func updateRedisClusterHosts(d *schema.ResourceData, meta interface{}) (err error) {
+	config := meta.(*Config)
+	ctx, cancel := redisReadContext(config, d)
+	defer cancel()
+
+	// Hosts and shards changed before a failed operation stay in the cluster, keep them in the state as well.
+	defer func() {
+		if err == nil {
+			return
+		}
+		readCtx, readCancel := redisReadContext(config, d)
+		defer readCancel()
+		if serr := setRedisHostsState(readCtx, d, config.sdk.MDB().Redis().Cluster()); serr != nil {
+			log.Printf("[WARN] Error while refreshing hosts of Redis Cluster %q after failed update: %s", d.Id(), serr)
+		}
+	}()
 
 	currHosts, err := listRedisHosts(ctx, config, d)
 	if err != nil {
@@ -585,7 +1240,7 @@ func updateRedisClusterHosts(d *schema.ResourceData, meta interface{}) error {
 
 	targetHosts, err := expandRedisHosts(d)
 	if err != nil {
-		return fmt.Errorf("Error while expanding hosts on Redis Cluster create: %s", err)
+		return fmt.Errorf("Error while expanding \"host\" of Redis Cluster %q: %s", d.Id(), err)
 	}
 
 	currShards, err := listRedisShards(ctx, config, d)
@@ -593,55 +1248,104 @@ func updateRedisClusterHosts(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	toDelete, toAdd := redisHostsDiff(currHosts, targetHosts)
+	autoRebalance := d.Get("auto_rebalance").(bool)
+	steps := planRedisHostsUpdate(currHosts, currShards, targetHosts, d.Get("sharded").(bool), autoRebalance)
+
+	// The timeouts aren't available while planning, so the timeout is checked right before the hosts are changed.
+	if warning := redisUpdateTimeoutWarning(steps, d.Timeout(schema.TimeoutUpdate)); warning != "" {
+		log.Printf("[WARN] Redis Cluster %q: %s", d.Id(), warning)
+	}
 
 	ctx, cancel = context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 
-	for shardName, specs := range toAdd {
-		shardExists := false
-		for _, s := range currShards {
-			if s.Name == shardName {
-				shardExists = true
-			}
+	for _, step := range steps {
+		opCtx, opCancel := redisHostOperationContext(ctx, d)
+		switch step.action {
+		case redisAddShard:
+			err = createRedisShard(opCtx, config, d, step.shardName, step.specs, autoRebalance)
+		case redisAddHosts:
+			err = createRedisHosts(opCtx, config, d, step.specs)
+		case redisDeleteShard:
+			err = deleteRedisShard(opCtx, config, d, step.shardName)
+		case redisDeleteHosts:
+			err = deleteRedisHosts(opCtx, config, d, step.fqdns)
+		case redisRebalance:
+			// With auto_rebalance disabled shards are added without rebalancing, so rebalance once at the end.
+			err = rebalanceRedisCluster(opCtx, config, d)
 		}
-		if sharded && !shardExists {
-			err = createRedisShard(ctx, config, d, shardName, specs)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = createRedisHosts(ctx, config, d, specs)
-			if err != nil {
-				return err
-			}
+		opCancel()
+		if err != nil {
+			return err
 		}
 	}
 
-	for shardName, fqdns := range toDelete {
-		deleteShard := true
-		for _, th := range targetHosts {
-			if th.ShardName == shardName {
-				deleteShard = false
-			}
-		}
-		if sharded && deleteShard {
-			err = deleteRedisShard(ctx, config, d, shardName)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = deleteRedisHosts(ctx, config, d, fqdns)
-			if err != nil {
-				return err
-			}
-		}
+	d.SetPartial("host")
+	return nil
+}
+
+// Stores the hosts the cluster actually has, ordered like in the config, so that hosts added or deleted
+// before a failed operation don't show up as drift on the next plan.
+func setRedisHostsState(ctx context.Context, d *schema.ResourceData, client ReducedRedisClusterServiceClient) error {
+	hosts, err := listRedisClusterHosts(ctx, client, d.Id(), false)
+	if err != nil {
+		return err
+	}
+
+	dHosts, err := expandRedisHosts(d)
+	if err != nil {
+		return err
+	}
+	sortRedisHosts(hosts, dHosts)
+
+	hs, err := flattenRedisHosts(hosts)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("host", hs); err != nil {
+		return err
+	}
+
+	if err := setRedisHostEndpoints(d, hs); err != nil {
+		return err
 	}
 
 	d.SetPartial("host")
 	return nil
 }
 
+func setRedisHostEndpoints(d *schema.ResourceData, hs []map[string]interface{}) error {
+	if err := d.Set("fqdns", flattenRedisHostFQDNs(hs)); err != nil {
+		return err
+	}
+
+	master, replicas, shardMasters, shardReplicas := flattenRedisHostEndpoints(hs, d.Get("sharded").(bool))
+	if err := d.Set("master_fqdn", master); err != nil {
+		return err
+	}
+	if err := d.Set("replica_fqdns", replicas); err != nil {
+		return err
+	}
+	if err := d.Set("shard_replica_fqdns", shardReplicas); err != nil {
+		return err
+	}
+	return d.Set("shard_master_fqdns", shardMasters)
+}
+
+// Each host and shard operation gets its own deadline derived from host_operation_timeout,
+// so a slow operation doesn't starve the following ones. The update timeout is still the upper bound.
+func redisHostOperationContext(ctx context.Context, d *schema.ResourceData) (context.Context, context.CancelFunc) {
+	v, ok := d.GetOk("host_operation_timeout")
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	timeout, err := time.ParseDuration(v.(string))
+	if err != nil || timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 func updateRedisMaintenanceWindow(ctx context.Context, config *Config, d *schema.ResourceData, mw *redis.MaintenanceWindow) error {
 	op, err := config.sdk.WrapOperation(
 		config.sdk.MDB().Redis().Cluster().Update(ctx, &redis.UpdateClusterRequest{
@@ -651,26 +1355,113 @@ func updateRedisMaintenanceWindow(ctx context.Context, config *Config, d *schema
 		}),
 	)
 	if err != nil {
-		return fmt.Errorf("error while requesting API to update maintenance window in Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("error while requesting API to update maintenance window in Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
-	err = op.Wait(ctx)
+	err = op.WaitInterval(ctx, config.operationPollInterval())
 	if err != nil {
-		return fmt.Errorf("error while updating maintenance window in Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("error while updating maintenance window in Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
 	return nil
 }
 
+// waitRedisOperation waits for the operation like op.Wait does, polling it every pollInterval and additionally
+// logging its progress at DEBUG level every redisOperationProgressInterval, so long running operations
+// like resharding don't look hung.
+// If the wait runs out of time, the operation is cancelled, see cancelRedisOperation.
+func waitRedisOperation(ctx context.Context, op *operation.Operation, pollInterval time.Duration) error {
+	err := waitOperationWithProgress(ctx, op, redisOperationProgressInterval, pollInterval)
+	if err == nil || op.Done() || ctx.Err() == nil {
+		return err
+	}
+	return cancelRedisOperation(op, err)
+}
+
+// Timeout of the cancellation of an operation, the context of its wait is already done by then.
+const redisOperationCancelTimeout = 1 * time.Minute
+
+// An operation left running after Terraform stopped waiting for it keeps changing the cluster, and the next apply
+// fails or conflicts with it, so it is cancelled. Not every MDB operation can be cancelled, then the error says so.
+func cancelRedisOperation(op *operation.Operation, waitErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOperationCancelTimeout)
+	defer cancel()
+
+	// the cancelled operation replaces the original one, which may lack its description
+	id, description := op.Id(), op.Description()
+	if err := op.Cancel(ctx); err != nil {
+		return fmt.Errorf("%s; operation %s (%s) couldn't be cancelled and may still be running: %s", waitErr, id, description, err)
+	}
+	return fmt.Errorf("%s; operation %s (%s) was cancelled", waitErr, id, description)
+}
+
+// op.WaitInterval can't log between the polls, so the operation is polled here in a single loop like op.WaitInterval
+// does, and its progress is logged at most every interval. The polls are not restarted by the logging, so pollInterval
+// is kept as is, even when it is longer than interval.
+func waitOperationWithProgress(ctx context.Context, op *operation.Operation, interval time.Duration, pollInterval time.Duration) error {
+	start := time.Now()
+	logged := start
+	notFound := 0
+	for !op.Done() {
+		if err := op.Poll(ctx); err != nil {
+			// the operation may be not on all replicas yet right after it is started
+			if status.Code(err) != codes.NotFound || notFound >= redisOperationNotFoundRetries {
+				return sdkerrors.WithMessagef(err, "operation (id=%s) poll fail", op.Id())
+			}
+			notFound++
+		}
+		if op.Done() {
+			break
+		}
+
+		if time.Since(logged) >= interval {
+			logged = time.Now()
+			// metadata is only informational here, so failing to unpack it is not an error
+			md, _ := op.Metadata()
+			log.Printf("[DEBUG] Operation %s (%s) is still running after %s, metadata: %v",
+				op.Id(), op.Description(), time.Since(start).Round(time.Second), md)
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return sdkerrors.WithMessagef(ctx.Err(), "operation (id=%s) wait context done", op.Id())
+		}
+	}
+	return sdkerrors.WithMessagef(op.Error(), "operation (id=%s) failed", op.Id())
+}
+
+type ReducedRedisClusterServiceClient interface {
+	Get(ctx context.Context, in *redis.GetClusterRequest, opts ...grpc.CallOption) (*redis.Cluster, error)
+	StartFailover(ctx context.Context, in *redis.StartClusterFailoverRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error)
+	Update(ctx context.Context, in *redis.UpdateClusterRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error)
+	Backup(ctx context.Context, in *redis.BackupClusterRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error)
+	ListBackups(ctx context.Context, in *redis.ListClusterBackupsRequest, opts ...grpc.CallOption) (*redis.ListClusterBackupsResponse, error)
+	RescheduleMaintenance(ctx context.Context, in *redis.RescheduleMaintenanceRequest, opts ...grpc.CallOption) (*ycoperation.Operation, error)
+	ListHosts(ctx context.Context, in *redis.ListClusterHostsRequest, opts ...grpc.CallOption) (*redis.ListClusterHostsResponse, error)
+}
+
 func listRedisHosts(ctx context.Context, config *Config, d *schema.ResourceData) ([]*redis.Host, error) {
+	return listRedisClusterHosts(ctx, config.sdk.MDB().Redis().Cluster(), d.Id(), false)
+}
+
+// listRedisClusterHosts returns hosts fetched so far instead of an error when allowPartial is set
+// and listing fails on any page except the first one
+func listRedisClusterHosts(ctx context.Context, client ReducedRedisClusterServiceClient, clusterID string, allowPartial bool) ([]*redis.Host, error) {
 	hosts := []*redis.Host{}
 	pageToken := ""
 	for {
-		resp, err := config.sdk.MDB().Redis().Cluster().ListHosts(ctx, &redis.ListClusterHostsRequest{
-			ClusterId: d.Id(),
+		resp, err := client.ListHosts(ctx, &redis.ListClusterHostsRequest{
+			ClusterId: clusterID,
 			PageSize:  defaultMDBPageSize,
 			PageToken: pageToken,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("Error while getting list of hosts for '%s': %s", d.Id(), err)
+			if allowPartial && pageToken != "" {
+				log.Printf("[WARN] Error while getting list of hosts for '%s', only %d hosts fetched so far are used: %s", clusterID, len(hosts), err)
+				return hosts, nil
+			}
+			return nil, fmt.Errorf("Error while getting list of hosts for '%s': %s", clusterID, err)
 		}
 		hosts = append(hosts, resp.Hosts...)
 		if resp.NextPageToken == "" {
@@ -702,7 +1493,7 @@ func listRedisShards(ctx context.Context, config *Config, d *schema.ResourceData
 	return shards, nil
 }
 
-func createRedisShard(ctx context.Context, config *Config, d *schema.ResourceData, shardName string, hostSpecs []*redis.HostSpec) error {
+func createRedisShard(ctx context.Context, config *Config, d *schema.ResourceData, shardName string, hostSpecs []*redis.HostSpec, rebalance bool) error {
 	op, err := config.sdk.WrapOperation(
 		config.sdk.MDB().Redis().Cluster().AddShard(ctx, &redis.AddClusterShardRequest{
 			ClusterId: d.Id(),
@@ -711,23 +1502,30 @@ func createRedisShard(ctx context.Context, config *Config, d *schema.ResourceDat
 		}),
 	)
 	if err != nil {
-		return fmt.Errorf("Error while requesting API to add shard to Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("Error while requesting API to add shard to Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
-	err = op.Wait(ctx)
+	err = waitRedisOperation(ctx, op, config.operationPollInterval())
 	if err != nil {
-		return fmt.Errorf("Error while adding shard to Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("Error while adding shard to Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
+	}
+	if !rebalance {
+		return nil
 	}
-	op, err = config.sdk.WrapOperation(
+	return rebalanceRedisCluster(ctx, config, d)
+}
+
+func rebalanceRedisCluster(ctx context.Context, config *Config, d *schema.ResourceData) error {
+	op, err := config.sdk.WrapOperation(
 		config.sdk.MDB().Redis().Cluster().Rebalance(ctx, &redis.RebalanceClusterRequest{
 			ClusterId: d.Id(),
 		}),
 	)
 	if err != nil {
-		return fmt.Errorf("Error while requesting API to rebalance the Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("Error while requesting API to rebalance the Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
-	err = op.Wait(ctx)
+	err = waitRedisOperation(ctx, op, config.operationPollInterval())
 	if err != nil {
-		return fmt.Errorf("Error while rebalancing the Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("Error while rebalancing the Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
 	return nil
 }
@@ -741,11 +1539,11 @@ func createRedisHosts(ctx context.Context, config *Config, d *schema.ResourceDat
 			}),
 		)
 		if err != nil {
-			return fmt.Errorf("Error while requesting API to add host to Redis Cluster %q: %s", d.Id(), err)
+			return fmt.Errorf("Error while requesting API to add host to Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 		}
-		err = op.Wait(ctx)
+		err = waitRedisOperation(ctx, op, config.operationPollInterval())
 		if err != nil {
-			return fmt.Errorf("Error while adding host to Redis Cluster %q: %s", d.Id(), err)
+			return fmt.Errorf("Error while adding host to Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 		}
 	}
 	return nil
@@ -759,11 +1557,11 @@ func deleteRedisShard(ctx context.Context, config *Config, d *schema.ResourceDat
 		}),
 	)
 	if err != nil {
-		return fmt.Errorf("Error while requesting API to delete shard from Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("Error while requesting API to delete shard from Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
-	err = op.Wait(ctx)
+	err = waitRedisOperation(ctx, op, config.operationPollInterval())
 	if err != nil {
-		return fmt.Errorf("Error while deleting shard from Redis Cluster %q: %s", d.Id(), err)
+		return fmt.Errorf("Error while deleting shard from Redis Cluster %q: %s", d.Id(), redisErrorWithRequestID(err))
 	}
 	return nil
 }
@@ -777,11 +1575,11 @@ func deleteRedisHosts(ctx context.Context, config *Config, d *schema.ResourceDat
 			}),
 		)
 		if err != nil {
-			return fmt.Errorf("Error while requesting API to delete host %s from Redis Cluster %q: %s", fqdn, d.Id(), err)
+			return fmt.Errorf("Error while requesting API to delete host %s from Redis Cluster %q: %s", fqdn, d.Id(), redisErrorWithRequestID(err))
 		}
-		err = op.Wait(ctx)
+		err = waitRedisOperation(ctx, op, config.operationPollInterval())
 		if err != nil {
-			return fmt.Errorf("Error while deleting host %s from Redis Cluster %q: %s", fqdn, d.Id(), err)
+			return fmt.Errorf("Error while deleting host %s from Redis Cluster %q: %s", fqdn, d.Id(), redisErrorWithRequestID(err))
 		}
 	}
 	return nil
@@ -793,16 +1591,90 @@ func makeRedisClusterUpdateRequest(req *redis.UpdateClusterRequest, d *schema.Re
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 
-	op, err := config.sdk.WrapOperation(config.sdk.MDB().Redis().Cluster().Update(ctx, req))
+	oldLabels, _ := d.GetChange("labels")
+	return updateRedisClusterRetryingConflicts(ctx, config.sdk.MDB().Redis().Cluster(), config.sdk.Operation(), req, oldLabels.(map[string]interface{}), config.operationPollInterval())
+}
+
+// Delay before the update conflicting with another operation on the cluster is retried, variable for tests.
+var redisUpdateConflictRetryDelay = 10 * time.Second
+
+// Another apply or operation running on the same cluster makes the API reject the update with FailedPrecondition
+// or Aborted. Such update is retried a few times against the refreshed cluster. Any other error, as well as
+// the failure of the update operation once it is started, fails the update immediately.
+func updateRedisClusterRetryingConflicts(ctx context.Context, client ReducedRedisClusterServiceClient, opClient operation.Client, req *redis.UpdateClusterRequest, oldLabels map[string]interface{}, pollInterval time.Duration) error {
+	for attempt := 0; ; attempt++ {
+		protoOp, err := client.Update(ctx, req)
+		if err == nil {
+			err = waitRedisOperation(ctx, operation.New(opClient, protoOp), pollInterval)
+			if err != nil {
+				return fmt.Errorf("Error updating Redis Cluster %q: %s", req.ClusterId, redisErrorWithRequestID(err))
+			}
+			return nil
+		}
+		if attempt >= redisUpdateConflictRetries || !isStatusWithCode(err, codes.FailedPrecondition) && !isStatusWithCode(err, codes.Aborted) {
+			return fmt.Errorf("Error updating Redis Cluster %q: %s", req.ClusterId, redisErrorWithRequestID(err))
+		}
+
+		log.Printf("[WARN] Update of Redis Cluster %q conflicts with another operation, retrying in %s: %s", req.ClusterId, redisUpdateConflictRetryDelay, err)
+		select {
+		case <-time.After(redisUpdateConflictRetryDelay):
+		case <-ctx.Done():
+			return fmt.Errorf("Error updating Redis Cluster %q: %s", req.ClusterId, redisErrorWithRequestID(err))
+		}
+
+		cluster, err := client.Get(ctx, &redis.GetClusterRequest{
+			ClusterId: req.ClusterId,
+		})
+		if err != nil {
+			return fmt.Errorf("Error while getting Redis Cluster %q to retry update: %s", req.ClusterId, redisErrorWithRequestID(err))
+		}
+		refreshRedisUpdateRequest(req, cluster, oldLabels)
+	}
+}
+
+// Labels are replaced as a whole, so system labels of the cluster, e.g. added by the conflicting operation, are kept.
+// System labels declared in the old configuration and missing in the new one are removed by the user, they are not kept.
+func refreshRedisUpdateRequest(req *redis.UpdateClusterRequest, cluster *redis.Cluster, oldLabels map[string]interface{}) {
+	for _, path := range req.UpdateMask.Paths {
+		if path != "labels" {
+			continue
+		}
+		if req.Labels == nil {
+			req.Labels = map[string]string{}
+		}
+		for k, v := range cluster.Labels {
+			_, declared := req.Labels[k]
+			_, removed := oldLabels[k]
+			if !declared && !removed && isMDBSystemLabel(k) {
+				req.Labels[k] = v
+			}
+		}
+	}
+}
+
+// Populates hosts of the imported cluster in a stable order, so that the following read keeps it
+// and the first plan after import doesn't show hosts as replaced.
+func resourceYandexMDBRedisClusterImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+
+	ctx, cancel := config.ContextWithTimeout(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	hosts, err := listRedisHosts(ctx, config, d)
 	if err != nil {
-		return fmt.Errorf("Error while requesting API to update Redis Cluster %q: %s", d.Id(), err)
+		return nil, fmt.Errorf("Error while importing hosts of Redis Cluster %q: %s", d.Id(), err)
 	}
+	sortRedisHostsByShardAndZone(hosts)
 
-	err = op.Wait(ctx)
+	hs, err := flattenRedisHosts(hosts)
 	if err != nil {
-		return fmt.Errorf("Error updating Redis Cluster %q: %s", d.Id(), err)
+		return nil, err
 	}
-	return nil
+	if err := d.Set("host", hs); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceYandexMDBRedisClusterDelete(d *schema.ResourceData, meta interface{}) error {
@@ -822,12 +1694,7 @@ func resourceYandexMDBRedisClusterDelete(d *schema.ResourceData, meta interface{
 		return handleNotFoundError(err, d, fmt.Sprintf("Redis Cluster %q", d.Get("name").(string)))
 	}
 
-	err = op.Wait(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = op.Response()
+	err = waitRedisClusterDeletion(ctx, op, config.operationPollInterval(), d.Id())
 	if err != nil {
 		return err
 	}
@@ -835,3 +1702,17 @@ func resourceYandexMDBRedisClusterDelete(d *schema.ResourceData, meta interface{
 	log.Printf("[DEBUG] Finished deleting Redis Cluster %q", d.Id())
 	return nil
 }
+
+// The cluster may disappear while the delete operation is running, e.g. when it is deleted out of band.
+// It is deleted either way, so NotFound is not an error here and destroy stays idempotent.
+func waitRedisClusterDeletion(ctx context.Context, op *operation.Operation, pollInterval time.Duration, clusterID string) error {
+	err := op.WaitInterval(ctx, pollInterval)
+	if err == nil {
+		_, err = op.Response()
+	}
+	if isStatusWithCode(err, codes.NotFound) {
+		log.Printf("[DEBUG] Redis Cluster %q is already deleted: %s", clusterID, err)
+		return nil
+	}
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/go-multierror"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+	rconfig "github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1/config"
 )
 
 const redisResource = "yandex_mdb_redis_cluster.foo"
@@ -67,9 +69,55 @@ func mdbRedisClusterImportStep(name string) resource.TestStep {
 		ImportState:       true,
 		ImportStateVerify: true,
 		ImportStateVerifyIgnore: []string{
-			"config.0.password", // not returned
-			"health",            // volatile value
-			"host",              // the order of hosts differs
+			"config.0.password",        // not returned
+			"password_set",             // depends on config.0.password
+			"health",                   // volatile value
+			"host",                     // the order of hosts differs
+			"reconcile_by_name",        // not returned
+			"allow_partial_hosts",      // not returned
+			"auto_rebalance",           // not returned
+			"host_operation_timeout",   // not returned
+			"failover_trigger",         // not returned
+			"backup_trigger",           // not returned
+			"allow_environment_change", // not returned
+			"allow_no_password",        // not returned
+			"require_host_redundancy",  // not returned
+			"maintenance_reschedule",   // not returned
+			"restore",                  // not returned
+			"wait_for_health",          // not returned
+			"detect_config_drift",      // not returned
+		},
+	}
+}
+
+// Checks that import populates all hosts of the cluster, including their shards
+func mdbRedisClusterImportHostsStep(name string, hostsCount int, shards []string) resource.TestStep {
+	return resource.TestStep{
+		ResourceName: name,
+		ImportState:  true,
+		ImportStateCheck: func(states []*terraform.InstanceState) error {
+			if len(states) != 1 {
+				return fmt.Errorf("Expected 1 imported state, got %d", len(states))
+			}
+			attrs := states[0].Attributes
+
+			if attrs["host.#"] != strconv.Itoa(hostsCount) {
+				return fmt.Errorf("Expected %d imported hosts, got %s", hostsCount, attrs["host.#"])
+			}
+			found := map[string]bool{}
+			for i := 0; i < hostsCount; i++ {
+				prefix := fmt.Sprintf("host.%d.", i)
+				if attrs[prefix+"zone"] == "" || attrs[prefix+"subnet_id"] == "" {
+					return fmt.Errorf("Imported host %d has no zone or subnet_id", i)
+				}
+				found[attrs[prefix+"shard_name"]] = true
+			}
+			for _, s := range shards {
+				if !found[s] {
+					return fmt.Errorf("Shard '%s' not found in imported hosts", s)
+				}
+			}
+			return nil
 		},
 	}
 }
@@ -114,6 +162,7 @@ func TestAccMDBRedisCluster_full(t *testing.T) {
 					resource.TestCheckResourceAttr(redisResource, "maintenance_window.0.type", "WEEKLY"),
 					resource.TestCheckResourceAttr(redisResource, "maintenance_window.0.day", "FRI"),
 					resource.TestCheckResourceAttr(redisResource, "maintenance_window.0.hour", "20"),
+					resource.TestCheckResourceAttr(redisResource, "password_set", "true"),
 				),
 			},
 			mdbRedisClusterImportStep(redisResource),
@@ -194,6 +243,7 @@ func TestAccMDBRedisCluster_sharded(t *testing.T) {
 				),
 			},
 			mdbRedisClusterImportStep(redisResourceSharded),
+			mdbRedisClusterImportHostsStep(redisResourceSharded, 6, []string{"first", "second", "third"}),
 			// Add new shard, delete old shard
 			{
 				Config: testAccMDBRedisShardedClusterConfigUpdated(redisName, redisDesc, version, baseDiskSize,
@@ -348,6 +398,136 @@ func TestAccMDBRedis6Cluster_sharded(t *testing.T) {
 	})
 }
 
+// Test that a Redis Cluster recreated out of band with the same name is adopted instead of being dropped from the state
+func TestAccMDBRedisCluster_reconcileByName(t *testing.T) {
+	t.Parallel()
+
+	var r redis.Cluster
+	redisName := acctest.RandomWithPrefix("tf-redis-reconcile")
+	clusterConfig := testAccMDBRedisClusterConfigBasic(redisName, "reconcile_by_name = true")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMDBRedisClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: clusterConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMDBRedisClusterExists(redisResource, &r, 1, false),
+				),
+			},
+			{
+				PreConfig: func() { testAccMDBRedisClusterRecreate(t, &r) },
+				Config:    clusterConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMDBRedisClusterIDChanged(redisResource, &r),
+					testAccCheckMDBRedisClusterExists(redisResource, &r, 1, false),
+					resource.TestCheckResourceAttr(redisResource, "name", redisName),
+				),
+			},
+		},
+	})
+}
+
+// Test that an explicit zero timeout, which never closes idle connections, is kept after apply and refresh
+func TestAccMDBRedisCluster_zeroTimeout(t *testing.T) {
+	t.Parallel()
+
+	var r redis.Cluster
+	redisName := acctest.RandomWithPrefix("tf-redis-timeout")
+	clusterConfig := testAccMDBRedisClusterConfigTimeout(redisName, 0)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMDBRedisClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: clusterConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMDBRedisClusterExists(redisResource, &r, 1, false),
+					resource.TestCheckResourceAttr(redisResource, "config.0.timeout", "0"),
+					func(s *terraform.State) error {
+						if timeout := extractRedisConfig(r.Config).timeout; timeout != 0 {
+							return fmt.Errorf("Expected timeout 0 of Redis Cluster %q, got %d", r.Id, timeout)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   clusterConfig,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// Deletes the cluster and creates a new one with the same name, hosts and resources.
+func testAccMDBRedisClusterRecreate(t *testing.T, r *redis.Cluster) {
+	conf := testAccProvider.Meta().(*Config)
+	ctx := context.Background()
+
+	resp, err := conf.sdk.MDB().Redis().Cluster().ListHosts(ctx, &redis.ListClusterHostsRequest{
+		ClusterId: r.Id,
+		PageSize:  defaultMDBPageSize,
+	})
+	if err != nil {
+		t.Fatalf("failed to list hosts of Redis Cluster %q: %s", r.Id, err)
+	}
+
+	var hostSpecs []*redis.HostSpec
+	for _, h := range resp.Hosts {
+		hostSpecs = append(hostSpecs, &redis.HostSpec{ZoneId: h.ZoneId, SubnetId: h.SubnetId, ShardName: h.ShardName})
+	}
+
+	op, err := conf.sdk.WrapOperation(conf.sdk.MDB().Redis().Cluster().Delete(ctx, &redis.DeleteClusterRequest{
+		ClusterId: r.Id,
+	}))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		t.Fatalf("failed to delete Redis Cluster %q: %s", r.Id, err)
+	}
+
+	op, err = conf.sdk.WrapOperation(conf.sdk.MDB().Redis().Cluster().Create(ctx, &redis.CreateClusterRequest{
+		FolderId:    r.FolderId,
+		Name:        r.Name,
+		Environment: r.Environment,
+		NetworkId:   r.NetworkId,
+		ConfigSpec: &redis.ConfigSpec{
+			Version:   r.Config.Version,
+			Resources: r.Config.Resources,
+			RedisSpec: &redis.ConfigSpec_RedisConfig_6_0{
+				RedisConfig_6_0: &rconfig.RedisConfig6_0{Password: "passw0rd"},
+			},
+		},
+		HostSpecs: hostSpecs,
+	}))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		t.Fatalf("failed to recreate Redis Cluster %q: %s", r.Name, err)
+	}
+}
+
+func testAccCheckMDBRedisClusterIDChanged(n string, r *redis.Cluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == r.Id {
+			return fmt.Errorf("Expected Redis Cluster ID to change after recreation, got the same ID %q", r.Id)
+		}
+		return nil
+	}
+}
+
 func testAccCheckMDBRedisClusterDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -846,3 +1026,57 @@ resource "yandex_mdb_redis_cluster" "bar" {
 }
 `, name, desc, version, diskSize, getDiskTypeStr(diskTypeId), getShardedHosts(diskTypeId, "new"))
 }
+
+// Minimal non-sharded Redis 6.0 cluster with a single host, extra top-level attributes are added as is.
+func testAccMDBRedisClusterConfigBasic(name string, extra string) string {
+	return fmt.Sprintf(redisVPCDependencies+`
+resource "yandex_mdb_redis_cluster" "foo" {
+  name        = "%s"
+  environment = "PRESTABLE"
+  network_id  = "${yandex_vpc_network.foo.id}"
+  %s
+
+  config {
+    password = "passw0rd"
+    version  = "6.0"
+  }
+
+  resources {
+    resource_preset_id = "hm1.nano"
+    disk_size          = 16
+  }
+
+  host {
+    zone      = "ru-central1-c"
+    subnet_id = "${yandex_vpc_subnet.foo.id}"
+  }
+}
+`, name, extra)
+}
+
+// Minimal non-sharded Redis 6.0 cluster with a single host and the given timeout.
+func testAccMDBRedisClusterConfigTimeout(name string, timeout int) string {
+	return fmt.Sprintf(redisVPCDependencies+`
+resource "yandex_mdb_redis_cluster" "foo" {
+  name        = "%s"
+  environment = "PRESTABLE"
+  network_id  = "${yandex_vpc_network.foo.id}"
+
+  config {
+    password = "passw0rd"
+    timeout  = %d
+    version  = "6.0"
+  }
+
+  resources {
+    resource_preset_id = "hm1.nano"
+    disk_size          = 16
+  }
+
+  host {
+    zone      = "ru-central1-c"
+    subnet_id = "${yandex_vpc_subnet.foo.id}"
+  }
+}
+`, name, timeout)
+}
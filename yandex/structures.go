@@ -418,6 +418,14 @@ func expandSecurityGroupIds(v interface{}) []string {
 	return m
 }
 
+// Returns a sorted copy of security group ids, so that the value read from the API is deterministic
+func flattenSecurityGroupIds(ids []string) []string {
+	res := make([]string, len(ids))
+	copy(res, ids)
+	sort.Strings(res)
+	return res
+}
+
 func expandHostGroupIds(v interface{}) []string {
 	if v == nil {
 		return nil
@@ -532,3 +532,21 @@ func TestExpandComputePrimaryV4AddressSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestFlattenSecurityGroupIds(t *testing.T) {
+	ids := []string{"sg-c", "sg-a", "sg-b"}
+	expected := []string{"sg-a", "sg-b", "sg-c"}
+
+	first := flattenSecurityGroupIds(ids)
+	second := flattenSecurityGroupIds([]string{"sg-b", "sg-c", "sg-a"})
+
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("%v not equals to %v", first, expected)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("%v not equals to %v", first, second)
+	}
+	if !reflect.DeepEqual(ids, []string{"sg-c", "sg-a", "sg-b"}) {
+		t.Errorf("source slice was modified: %v", ids)
+	}
+}
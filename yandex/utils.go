@@ -456,6 +456,17 @@ func getTimestamp(protots *timestamp.Timestamp) (string, error) {
 	return ts.Format(defaultTimeFormat), nil
 }
 
+// Same as getTimestamp, but logs a warning and returns an empty string for a malformed timestamp,
+// so that a single bad value doesn't make the whole resource un-refreshable.
+func getTimestampOrEmpty(protots *timestamp.Timestamp, attr string) string {
+	ts, err := getTimestamp(protots)
+	if err != nil {
+		log.Printf("[WARN] Unable to parse %s, leaving it empty: %s", attr, err)
+		return ""
+	}
+	return ts
+}
+
 func stringSliceToLower(s []string) []string {
 	var ret []string
 	for _, v := range s {
@@ -723,3 +734,51 @@ func fieldDeprecatedForAnother(deprecatedFieldName string, newFieldName string)
 func getSDK(config *Config) *ycsdk.SDK {
 	return config.sdk
 }
+
+// Returns up to n candidates with the smallest edit distance to value, closest first.
+func nearestStrings(value string, candidates []string, n int) []string {
+	sorted := make([]string, len(candidates))
+	copy(sorted, candidates)
+
+	distances := make(map[string]int, len(sorted))
+	for _, c := range sorted {
+		distances[c] = levenshteinDistance(value, c)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return distances[sorted[i]] < distances[sorted[j]]
+	})
+
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
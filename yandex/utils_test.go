@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
@@ -493,3 +494,19 @@ func testAccCheckResourceAttrWithValueFactory(name, key string, valueFactory fun
 		return nil
 	}
 }
+
+func TestGetTimestampOrEmpty(t *testing.T) {
+	assert.Equal(t, "", getTimestampOrEmpty(nil, "created_at"))
+
+	// seconds out of the range supported by protobuf timestamps
+	assert.Equal(t, "", getTimestampOrEmpty(&timestamp.Timestamp{Seconds: -62135596801}, "created_at"))
+
+	ts := time.Date(2021, 7, 1, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, ts.Format(defaultTimeFormat), getTimestampOrEmpty(&timestamp.Timestamp{Seconds: ts.Unix()}, "created_at"))
+
+	// timestamps are RFC3339 in UTC, fractions of a second are dropped
+	assert.Equal(t, "2021-07-01T12:30:00Z", getTimestampOrEmpty(&timestamp.Timestamp{Seconds: ts.Unix(), Nanos: 500}, "created_at"))
+	parsed, err := time.Parse(time.RFC3339, getTimestampOrEmpty(&timestamp.Timestamp{Seconds: ts.Unix()}, "created_at"))
+	assert.NoError(t, err)
+	assert.True(t, ts.Equal(parsed))
+}